@@ -0,0 +1,32 @@
+package runy
+
+import (
+	"context"
+	"io"
+)
+
+// CleanupFunc releases a resource, e.g. closes a database pool or flushes a tracer provider.
+type CleanupFunc func(ctx context.Context) error
+
+// FromCleanup returns a Runnable for a resource that doesn't run anything but must be released
+// when the Group shuts down. The Runnable is ready right away and calls cleanup once its context
+// is canceled. Since a Group stops its Runnables in the reverse order of their start,
+// resources are released in the reverse order of registration, after the Runnables registered later,
+// which may still use them, have stopped. Like the Stop method with FromSugared, cleanup gets
+// the canceled context by default; use WithStopTimeout to give it time, e.g. to flush a tracer provider.
+// The Strategy of the Group (see WithStrategy) doesn't stop the resource along with the siblings it restarts,
+// so the restarted Runnables keep using it. It's released only on shutdown or when removed (see Handle).
+// Nothing is released if the Group never starts the Runnable, e.g. when Group.Start returns ErrDependencyCycle,
+// so release the resource on such early returns yourself.
+func FromCleanup(cleanup CleanupFunc, opts ...FromSugaredOption) Runnable {
+	rn := newStartStop(SugaredFromFuncs(nil, StopFunc(cleanup)), opts)
+	rn.cleanup = true
+	return rn
+}
+
+// FromCloser returns a Runnable that closes c when the Group shuts down. See FromCleanup.
+func FromCloser(c io.Closer, opts ...FromSugaredOption) Runnable {
+	return FromCleanup(func(context.Context) error {
+		return c.Close()
+	}, opts...)
+}
@@ -0,0 +1,114 @@
+package runy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
+
+func TestFromCleanup(t *testing.T) {
+	t.Run("reverse order", func(t *testing.T) {
+		g := NewGroup()
+
+		var mu sync.Mutex
+		var released []string
+		release := func(name string) {
+			mu.Lock()
+			defer mu.Unlock()
+			released = append(released, name)
+		}
+		g.Add(FromCleanup(func(ctx context.Context) error {
+			release("db")
+			return nil
+		}))
+		g.Add(FromCloser(closerFunc(func() error {
+			release("cache")
+			return nil
+		})))
+		g.NextPhase()
+		g.Add(RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			release("server")
+			return nil
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() { errCh <- g.Start(ctx) }()
+		assert.NoError(t, g.WaitReady(context.Background()))
+		mu.Lock()
+		assert.Empty(t, released)
+		mu.Unlock()
+
+		cancel()
+		assert.NoError(t, <-errCh)
+		assert.Equal(t, []string{"server", "cache", "db"}, released)
+	})
+
+	t.Run("strategy restart", func(t *testing.T) {
+		for _, strategy := range []Strategy{OneForAll, RestForOne} {
+			t.Run(strategy.String(), func(t *testing.T) {
+				g := NewGroup(WithStrategy(strategy, WithBackoff(Backoff{})))
+
+				var mu sync.Mutex
+				var events []string
+				record := func(event string) {
+					mu.Lock()
+					defer mu.Unlock()
+					events = append(events, event)
+				}
+				g.Add(FromCleanup(func(ctx context.Context) error {
+					record("release")
+					return nil
+				}))
+				var starts int
+				g.AddF(func(ctx context.Context) error {
+					starts++
+					record(fmt.Sprintf("start %d", starts))
+					if starts == 1 {
+						return assert.AnError
+					}
+					<-ctx.Done()
+					return nil
+				})
+
+				ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+				defer cancel()
+				assert.NoError(t, g.Start(ctx))
+				assert.Equal(t, []string{"start 1", "start 2", "release"}, events)
+			})
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		g := NewGroup()
+		g.Add(Named("db", FromCloser(closerFunc(func() error { return assert.AnError }))))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := g.Start(ctx)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Contains(t, fmt.Sprint(err), "db")
+	})
+
+	t.Run("stop timeout", func(t *testing.T) {
+		var deadline bool
+		rn := FromCleanup(func(ctx context.Context) error {
+			_, deadline = ctx.Deadline()
+			return ctx.Err()
+		}, WithStopTimeout(time.Minute))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, rn.Start(ctx))
+		assert.True(t, deadline)
+	})
+}
@@ -0,0 +1,59 @@
+package runy
+
+import (
+	"context"
+	"time"
+)
+
+// detach returns a context that keeps the values of parent but is never canceled.
+// It's a replacement for context.WithoutCancel, which is only available since Go 1.21.
+func detach(parent context.Context) context.Context {
+	return detachedContext{parent: parent}
+}
+
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key any) any {
+	return c.parent.Value(key)
+}
+
+type restartHookKey struct{}
+
+// withRestartHook returns a copy of ctx carrying fn, which Supervise calls with the error
+// of the Runnable each time it restarts it.
+func withRestartHook(ctx context.Context, fn func(err error)) context.Context {
+	return context.WithValue(ctx, restartHookKey{}, fn)
+}
+
+// restartHook returns the function set by withRestartHook, or nil.
+func restartHook(ctx context.Context) func(err error) {
+	fn, _ := ctx.Value(restartHookKey{}).(func(err error))
+	return fn
+}
+
+type nameKey struct{}
+
+// withName returns a copy of ctx carrying the name of the Runnable it's passed to by a Group.
+func withName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, nameKey{}, name)
+}
+
+// nameFrom returns the name set by withName, if any.
+func nameFrom(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(nameKey{}).(string)
+	return name, ok
+}
@@ -0,0 +1,142 @@
+package runy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrMissingDependency is returned by Group.Start when a Runnable depends on a name
+	// that no registered Runnable has.
+	ErrMissingDependency = errors.New("missing dependency")
+	// ErrDependencyCycle is returned by Group.Start when Runnables depend on each other in a cycle.
+	ErrDependencyCycle = errors.New("dependency cycle")
+)
+
+// DependsOn returns a Runnable that a Group starts only after all the Runnables with the given names
+// (see Named) are ready (see ReadyNotifier) or have finished, and stops before them.
+// If several Runnables have the same name, rn depends on all of them.
+// A dependency that isn't a ReadyNotifier is up only once it has finished, e.g. a migration,
+// so depending on a long-running one keeps rn from ever starting. The Group logs a warning
+// for such dependencies (see WithLogAdapter); make them ReadyNotifiers, e.g. with ReadySignal.
+func DependsOn(rn Runnable, names ...string) Runnable {
+	return &dependsOnRunnable{rn: rn, names: names}
+}
+
+type dependsOnRunnable struct {
+	rn    Runnable
+	names []string
+}
+
+func (r *dependsOnRunnable) Start(ctx context.Context) error {
+	return r.rn.Start(ctx)
+}
+
+func (r *dependsOnRunnable) Unwrap() Runnable {
+	return r.rn
+}
+
+// resolve links the units to their dependencies and sorts them topologically.
+// The units of a phase depend on all the units of the previous phase.
+// The registration order is kept as long as the dependencies allow it.
+func resolve(units []*unit) ([]*unit, error) {
+	for _, u := range units {
+		if err := link(u, units); err != nil {
+			return nil, err
+		}
+	}
+
+	sorted := make([]*unit, 0, len(units))
+	placed := make(map[*unit]bool, len(units))
+	for len(sorted) < len(units) {
+		progress := false
+	next:
+		for _, u := range units {
+			if placed[u] {
+				continue
+			}
+			for _, dep := range u.deps {
+				if !placed[dep] {
+					continue next
+				}
+			}
+			u.index = len(sorted)
+			sorted = append(sorted, u)
+			placed[u] = true
+			progress = true
+			break // start over to keep the registration order
+		}
+		if !progress {
+			return nil, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(findCycle(units, placed), " -> "))
+		}
+	}
+	return sorted, nil
+}
+
+// link sets the dependencies of the unit among the units:
+// the units of the previous phase and the units with the names the unit depends on.
+func link(u *unit, units []*unit) error {
+	u.deps, u.waitsExit = nil, nil
+	for _, prev := range units {
+		if prev.phase == u.phase-1 {
+			u.deps = append(u.deps, prev)
+		}
+	}
+	for rn := u.rn; rn != nil; rn = unwrapOnce(rn) {
+		d, ok := rn.(*dependsOnRunnable)
+		if !ok {
+			continue
+		}
+		for _, name := range d.names {
+			var found bool
+			for _, dep := range units {
+				if dep.name == name {
+					u.deps = append(u.deps, dep)
+					found = true
+					if _, ok := find[ReadyNotifier](dep.rn); !ok {
+						u.waitsExit = append(u.waitsExit, dep.name)
+					}
+				}
+			}
+			if !found {
+				return fmt.Errorf("%w: %s depends on %s", ErrMissingDependency, u.name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// findCycle returns the names of the units forming a dependency cycle among the units that aren't placed.
+func findCycle(units []*unit, placed map[*unit]bool) []string {
+	var u *unit
+	for _, c := range units {
+		if !placed[c] {
+			u = c
+			break
+		}
+	}
+
+	// Every unit that isn't placed has a dependency that isn't placed either,
+	// so following them eventually leads to a cycle.
+	var path []*unit
+	seen := make(map[*unit]int)
+	for {
+		if i, ok := seen[u]; ok {
+			names := make([]string, 0, len(path)-i+1)
+			for _, c := range path[i:] {
+				names = append(names, c.name)
+			}
+			return append(names, u.name)
+		}
+		seen[u] = len(path)
+		path = append(path, u)
+		for _, dep := range u.deps {
+			if !placed[dep] {
+				u = dep
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package runy
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependsOn(t *testing.T) {
+	// component returns a Runnable that records its start and stop and is ready once started.
+	component := func(mu *sync.Mutex, events *[]string, name string, deps ...string) Runnable {
+		rn := newReadyRunnableFunc(nil)
+		rn.run = func(ctx context.Context) error {
+			mu.Lock()
+			*events = append(*events, "start "+name)
+			mu.Unlock()
+			close(rn.ready)
+
+			<-ctx.Done()
+			mu.Lock()
+			*events = append(*events, "stop "+name)
+			mu.Unlock()
+			return nil
+		}
+		return Named(name, DependsOn(rn, deps...))
+	}
+
+	t.Run("topological order", func(t *testing.T) {
+		var mu sync.Mutex
+		var events []string
+		g := NewGroup()
+		g.Add(
+			component(&mu, &events, "http", "cache", "auth"),
+			component(&mu, &events, "cache", "db"),
+			component(&mu, &events, "auth"),
+			component(&mu, &events, "db"),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.NoError(t, g.Start(ctx))
+
+		index := func(event string) int {
+			for i, e := range events {
+				if e == event {
+					return i
+				}
+			}
+			return -1
+		}
+		assert.Less(t, index("start db"), index("start cache"))
+		assert.Less(t, index("start cache"), index("start http"))
+		assert.Less(t, index("start auth"), index("start http"))
+		// Started in order auth, db, cache, http, which keeps the registration order where possible.
+		assert.Equal(t, []string{"stop http", "stop cache", "stop db", "stop auth"}, events[4:])
+	})
+
+	t.Run("dependency without readiness", func(t *testing.T) {
+		l := &recordingLogger{}
+		g := NewGroup(WithLogAdapter(l))
+		g.Add(Named("migration", RunnableFunc(func(ctx context.Context) error { return nil })))
+		var started bool
+		g.Add(Named("http", DependsOn(RunnableFunc(func(ctx context.Context) error {
+			started = true
+			return nil
+		}), "migration")))
+
+		assert.NoError(t, g.Start(context.Background()))
+		assert.True(t, started)
+		assert.Contains(t, l.get(), "WARN runnable depends on a runnable that isn't a ReadyNotifier, it starts only once the dependency has finished")
+	})
+
+	t.Run("missing dependency", func(t *testing.T) {
+		var started bool
+		g := NewGroup()
+		g.AddF(func(ctx context.Context) error {
+			started = true
+			return nil
+		})
+		g.Add(Named("http", DependsOn(RunnableFunc(func(ctx context.Context) error { return nil }), "cache")))
+
+		err := g.Start(context.Background())
+		assert.ErrorIs(t, err, ErrMissingDependency)
+		assert.EqualError(t, err, "missing dependency: http depends on cache")
+		assert.False(t, started)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		var started bool
+		g := NewGroup()
+		g.AddF(func(ctx context.Context) error {
+			started = true
+			return nil
+		})
+		g.Add(
+			Named("a", DependsOn(RunnableFunc(func(ctx context.Context) error { return nil }), "b")),
+			Named("b", DependsOn(RunnableFunc(func(ctx context.Context) error { return nil }), "c")),
+			Named("c", DependsOn(RunnableFunc(func(ctx context.Context) error { return nil }), "a")),
+		)
+
+		err := g.Start(context.Background())
+		assert.ErrorIs(t, err, ErrDependencyCycle)
+		assert.EqualError(t, err, "dependency cycle: a -> b -> c -> a")
+		assert.False(t, started)
+	})
+
+	t.Run("cycle with phases", func(t *testing.T) {
+		g := NewGroup()
+		g.Add(Named("a", DependsOn(RunnableFunc(func(ctx context.Context) error { return nil }), "b")))
+		g.NextPhase().Add(Named("b", RunnableFunc(func(ctx context.Context) error { return nil })))
+
+		assert.ErrorIs(t, g.Start(context.Background()), ErrDependencyCycle)
+	})
+
+	t.Run("same name", func(t *testing.T) {
+		g := NewGroup()
+		var workers int
+		var mu sync.Mutex
+		worker := RunnableFunc(func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			workers++
+			return nil
+		})
+		g.Add(Named("dispatcher", DependsOn(RunnableFunc(func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, 2, workers)
+			return nil
+		}), "worker")))
+		g.Add(Named("worker", worker), Named("worker", worker))
+
+		assert.NoError(t, g.Start(context.Background()))
+	})
+}
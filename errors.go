@@ -0,0 +1,181 @@
+package runy
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAbandoned is returned by Group.Start when a Runnable doesn't stop within its stop timeout.
+// Group.Start doesn't wait for abandoned Runnables to return.
+// It's also returned by the Runnables created by FromSugared when Start doesn't return after Stop
+// (see WithJoinTimeout).
+var ErrAbandoned = errors.New("abandoned")
+
+// ErrNotReady is returned by Group.WaitReady when Group.Start returns before all Runnables are ready.
+var ErrNotReady = errors.New("group stopped before all runnables were ready")
+
+// ErrGroupStarted is returned by Group.Start and Group.Reset when the Group is already started and hasn't stopped yet.
+var ErrGroupStarted = errors.New("group already started")
+
+// ErrGroupStopping is returned by Group.Spawn and recorded by Group.Add
+// when a Runnable is registered while the Group is stopping.
+var ErrGroupStopping = errors.New("group is stopping")
+
+// ErrUnexpectedExit is returned by the Runnables created by FromSugared
+// when Start returns nil before the context is canceled (see WithEarlyExit).
+var ErrUnexpectedExit = errors.New("unexpected exit")
+
+// ErrStopGroup can be returned by a Runnable to stop the Group it runs in without an error.
+// A Group doesn't report it and neither a Strategy nor Supervise restarts the Runnable.
+// Outside a Group, it's returned as is (see EarlyExitStopGroup).
+var ErrStopGroup = errors.New("stop group")
+
+// Stage is a stage of the Runnable lifecycle.
+type Stage string
+
+const (
+	// StageStart is the stage from Runnable start until its context is canceled by the Group.
+	StageStart Stage = "start"
+	// StageStop is the stage from Runnable context cancellation until it returns.
+	StageStop Stage = "stop"
+)
+
+// RunnableError is returned by Group.Start when a Runnable fails.
+type RunnableError struct {
+	// Name is the name of the Runnable (see Named).
+	Name string
+	// Stage is the lifecycle stage in which the Runnable failed.
+	Stage Stage
+	// Uptime is how long the Runnable had been running when it failed.
+	Uptime time.Duration
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *RunnableError) Error() string {
+	return fmt.Sprintf("runnable %s: %s after %s: %v", e.Name, e.Stage, e.Uptime.Round(time.Millisecond), e.Err)
+}
+
+func (e *RunnableError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is reported by Group.Start when a Runnable panics (see WithPanicRecovery).
+type PanicError struct {
+	// Name is the name of the Runnable that panicked.
+	Name string
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack []byte
+}
+
+// newPanicError creates a PanicError from a recovered value.
+// It must be called in the deferred function that recovered p to capture the right stack trace.
+func newPanicError(p any) *PanicError {
+	if pErr, ok := p.(*PanicError); ok {
+		return pErr
+	}
+	return &PanicError{Value: p, Stack: debug.Stack()}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the panic value if it's an error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// ShutdownTimeoutError is returned by Group.Start when the Runnables don't stop
+// within the shutdown timeout of the Group.
+type ShutdownTimeoutError struct {
+	// Timeout is the shutdown timeout that was exceeded.
+	Timeout time.Duration
+	// Running holds the names of the Runnables that were still running.
+	Running []string
+}
+
+func (e *ShutdownTimeoutError) Error() string {
+	return fmt.Sprintf("shutdown timeout %s exceeded, still running: %s", e.Timeout, strings.Join(e.Running, ", "))
+}
+
+// MultiError is returned by Group.Start and holds all the errors that occurred
+// during the run and the shutdown of the Group in the order they occurred.
+// It supports errors.Is and errors.As, which examine each of the errors.
+type MultiError struct {
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
+
+// RunnableErrors returns the errors of the Runnables grouped by Runnable name.
+func (e *MultiError) RunnableErrors() map[string][]*RunnableError {
+	m := make(map[string][]*RunnableError)
+	for _, err := range e.Errors {
+		var rnErr *RunnableError
+		if errors.As(err, &rnErr) {
+			m[rnErr.Name] = append(m[rnErr.Name], rnErr)
+		}
+	}
+	return m
+}
+
+// errorCollector collects errors from concurrent goroutines.
+// Errors added after close are dropped.
+type errorCollector struct {
+	mu     sync.Mutex
+	errs   []error
+	closed bool
+}
+
+func (c *errorCollector) add(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.errs = append(c.errs, err)
+	}
+}
+
+// close returns the collected errors as a *MultiError, or nil if there are none.
+func (c *errorCollector) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: c.errs}
+}
+
+// joinErrors is like errors.Join, but returns the error as is if there is a single one.
+func joinErrors(errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	if len(nonNil) == 1 {
+		return nonNil[0]
+	}
+	return errors.Join(nonNil...)
+}
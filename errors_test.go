@@ -0,0 +1,50 @@
+package runy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunnableError(t *testing.T) {
+	err := &RunnableError{Name: "http", Stage: StageStop, Uptime: 1500 * time.Microsecond, Err: assert.AnError}
+	assert.EqualError(t, err, "runnable http: stop after 2ms: "+assert.AnError.Error())
+	assert.True(t, errors.Is(err, assert.AnError))
+}
+
+func TestPanicError(t *testing.T) {
+	err := &PanicError{Name: "http", Value: "boom", Stack: []byte("goroutine 1 [running]:")}
+	assert.EqualError(t, err, "panic: boom\n\ngoroutine 1 [running]:")
+	assert.Nil(t, err.Unwrap())
+
+	err = &PanicError{Name: "http", Value: assert.AnError}
+	assert.True(t, errors.Is(err, assert.AnError))
+
+	assert.Same(t, err, newPanicError(err))
+}
+
+func TestShutdownTimeoutError(t *testing.T) {
+	err := &ShutdownTimeoutError{Timeout: 5 * time.Second, Running: []string{"http", "kafka"}}
+	assert.EqualError(t, err, "shutdown timeout 5s exceeded, still running: http, kafka")
+}
+
+func TestMultiError(t *testing.T) {
+	errHTTP := &RunnableError{Name: "http", Stage: StageStop, Err: assert.AnError}
+	errKafka := &RunnableError{Name: "kafka", Stage: StageStart, Err: errors.New("broker")}
+	errTimeout := &ShutdownTimeoutError{Timeout: time.Second, Running: []string{"kafka"}}
+	err := &MultiError{Errors: []error{errHTTP, errKafka, errTimeout}}
+
+	assert.EqualError(t, err, errHTTP.Error()+"; "+errKafka.Error()+"; "+errTimeout.Error())
+	assert.True(t, errors.Is(err, assert.AnError))
+
+	var timeoutErr *ShutdownTimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, errTimeout, timeoutErr)
+
+	assert.Equal(t, map[string][]*RunnableError{
+		"http":  {errHTTP},
+		"kafka": {errKafka},
+	}, err.RunnableErrors())
+}
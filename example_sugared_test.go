@@ -25,12 +25,10 @@ func Example_sugared() {
 	runy.SAddF(func(_ context.Context) error {
 		log.Printf("http server starts listening on: %s", httpSrv.Addr)
 		return runy.IgnoreHTTPServerClosed(httpSrv.ListenAndServe())
-	}, func(_ context.Context) error {
-		// Graceful shutdown with timeout when context is canceled.
-		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
-		defer cancel()
+	}, func(ctx context.Context) error {
+		// Graceful shutdown when context is canceled, the stop context is bounded by WithStopTimeout.
 		return httpSrv.Shutdown(ctx)
-	})
+	}, runy.WithStopTimeout(shutdownTimeout))
 
 	// Register management server using the same pattern.
 	runy.SAddF(func(_ context.Context) error {
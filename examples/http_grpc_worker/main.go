@@ -20,16 +20,18 @@ func run() error {
 	// Create a context that's canceled when SIGINT or SIGTERM is received.
 	ctx := runy.SetupSignalHandler()
 
-	_, cleanup, err := initWithCleanup(1)
+	_, cleanup1, err := initWithCleanup(1)
 	if err != nil {
 		return fmt.Errorf("failed to init 1: %w", err)
 	}
-	defer cleanup()
-	_, cleanup, err = initWithCleanup(2)
+	_, cleanup2, err := initWithCleanup(2)
 	if err != nil {
+		_ = cleanup1(context.Background())
 		return fmt.Errorf("failed to init 2: %w", err)
 	}
-	defer cleanup()
+	// Release the resources on shutdown, after the components registered later have stopped.
+	// Nothing is released if Start rejects the setup before starting them, e.g. on ErrDependencyCycle.
+	runy.Add(runy.FromCleanup(cleanup1), runy.FromCleanup(cleanup2))
 
 	// Initialize and register application components with runy.
 	httpSrv := runnables.NewHTTPServer(runnables.HTTPServerConfig{
@@ -46,6 +48,13 @@ func run() error {
 	worker := NewWorker(2 * time.Second)
 	runy.Add(httpSrv, grpcSrv, mgmtSrv, worker)
 
+	// Log once the servers are listening.
+	go func() {
+		if err := runy.WaitReady(ctx); err == nil {
+			log.Println("app is ready")
+		}
+	}()
+
 	// Start all components and block until shutdown.
 	log.Println("starting app")
 	if err := runy.Start(ctx); err != nil {
@@ -54,10 +63,11 @@ func run() error {
 	return nil
 }
 
-func initWithCleanup(i int) (any, func(), error) {
+func initWithCleanup(i int) (any, runy.CleanupFunc, error) {
 	log.Printf("init %d", i)
-	return nil, func() {
+	return nil, func(context.Context) error {
 		log.Printf("cleanup %d", i)
+		return nil
 	}, nil
 }
 
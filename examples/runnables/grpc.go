@@ -6,12 +6,15 @@ import (
 	"log"
 	"net"
 
+	"github.com/belo4ya/runy"
 	"google.golang.org/grpc"
 )
 
 type GRPCServer struct {
 	GRPC *grpc.Server
 	conf GRPCServerConfig
+
+	runy.ReadySignal // ready once the server is listening
 }
 
 type GRPCServerConfig struct {
@@ -27,6 +30,7 @@ func (s *GRPCServer) Start(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("net listen: %w", err)
 	}
+	s.SignalReady()
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -5,13 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"time"
+
+	"github.com/belo4ya/runy"
 )
 
 type HTTPServer struct {
 	HTTP *http.Server
 	conf HTTPServerConfig
+
+	runy.ReadySignal // ready once the server is listening
 }
 
 type HTTPServerConfig struct {
@@ -28,11 +33,17 @@ func NewHTTPServer(conf HTTPServerConfig) *HTTPServer {
 }
 
 func (s *HTTPServer) Start(ctx context.Context) error {
+	lis, err := net.Listen("tcp", s.conf.Addr)
+	if err != nil {
+		return fmt.Errorf("net listen: %w", err)
+	}
+	s.SignalReady()
+
 	errCh := make(chan error, 1)
 	go func() {
 		log.Printf("http server starts listening on: %s", s.conf.Addr)
-		if err := s.HTTP.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-			errCh <- fmt.Errorf("http listen and serve: %w", err)
+		if err := s.HTTP.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+			errCh <- fmt.Errorf("http serve: %w", err)
 		}
 		close(errCh)
 	}()
@@ -23,16 +23,18 @@ func run() error {
 	// Create a context that's canceled when SIGINT or SIGTERM is received.
 	ctx := runy.SetupSignalHandler()
 
-	_, cleanup, err := initWithCleanup(1)
+	_, cleanup1, err := initWithCleanup(1)
 	if err != nil {
 		return fmt.Errorf("failed to init 1: %w", err)
 	}
-	defer cleanup()
-	_, cleanup, err = initWithCleanup(2)
+	_, cleanup2, err := initWithCleanup(2)
 	if err != nil {
+		_ = cleanup1(context.Background())
 		return fmt.Errorf("failed to init 2: %w", err)
 	}
-	defer cleanup()
+	// Release the resources on shutdown, after the components registered later have stopped.
+	// Nothing is released if Start rejects the setup before starting them, e.g. on ErrDependencyCycle.
+	runy.Add(runy.FromCleanup(cleanup1), runy.FromCleanup(cleanup2))
 
 	httpSrv := &http.Server{Addr: ":8080"}
 	grpcSrv := grpc.NewServer()
@@ -42,10 +44,10 @@ func run() error {
 	runy.SAddF(func(_ context.Context) error {
 		log.Printf("http server starts listening on: %s", httpSrv.Addr)
 		return runy.IgnoreHTTPServerClosed(httpSrv.ListenAndServe())
-	}, func(_ context.Context) error {
+	}, func(ctx context.Context) error {
 		log.Println("shutting down http server")
-		return httpSrv.Shutdown(context.Background())
-	})
+		return httpSrv.Shutdown(ctx)
+	}, runy.WithStopTimeout(3*time.Second))
 
 	// Register GRPC server.
 	runy.SAddF(func(_ context.Context) error {
@@ -85,9 +87,10 @@ func run() error {
 	return nil
 }
 
-func initWithCleanup(i int) (any, func(), error) {
+func initWithCleanup(i int) (any, runy.CleanupFunc, error) {
 	log.Printf("init %d", i)
-	return nil, func() {
+	return nil, func(context.Context) error {
 		log.Printf("cleanup %d", i)
+		return nil
 	}, nil
 }
@@ -5,11 +5,8 @@ go 1.20
 require (
 	github.com/stretchr/testify v1.10.0
 	go.uber.org/goleak v1.3.0
-	golang.org/x/sync v0.12.0
 )
 
-replace golang.org/x/sync => golang.org/x/sync v0.11.0
-
 require (
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
 	github.com/kr/pretty v0.3.1 // indirect
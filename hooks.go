@@ -0,0 +1,182 @@
+package runy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Hooks observes the lifecycle of a Group (see WithHooks).
+// The methods are called synchronously, possibly from different goroutines,
+// so they must be safe for concurrent use and return quickly.
+// Embed NopHooks to implement only some of the methods.
+type Hooks interface {
+	// OnStart is called when the Group starts a Runnable, including on restarts.
+	OnStart(name string)
+	// OnReady is called when a Runnable that is a ReadyNotifier reports readiness.
+	OnReady(name string)
+	// OnStop is called when a Runnable returns, with the error it returned, if any.
+	OnStop(name string, err error)
+	// OnError is called when a Runnable fails: returns an error, panics, is abandoned
+	// (see StopTimeout) or exhausts its restarts.
+	OnError(name string, err error)
+	// OnRestart is called before a Runnable is restarted by the Strategy of the Group or by Supervise,
+	// with the error that caused the restart, if any.
+	OnRestart(name string, err error)
+	// OnShutdownBegin is called when the Group starts stopping its Runnables.
+	OnShutdownBegin()
+	// OnShutdownEnd is called when the Group has stopped its Runnables, with the error Group.Start returns.
+	OnShutdownEnd(err error)
+}
+
+// StoppingHooks is an optional interface that Hooks can implement
+// to be notified when the Group starts stopping a Runnable.
+type StoppingHooks interface {
+	// OnStopping is called when the Group cancels the context of a Runnable to stop it,
+	// unless the Runnable has already returned.
+	OnStopping(name string)
+}
+
+// NopHooks implements Hooks with methods that do nothing.
+type NopHooks struct{}
+
+func (NopHooks) OnStart(string) {}
+
+func (NopHooks) OnReady(string) {}
+
+func (NopHooks) OnStop(string, error) {}
+
+func (NopHooks) OnError(string, error) {}
+
+func (NopHooks) OnRestart(string, error) {}
+
+func (NopHooks) OnShutdownBegin() {}
+
+func (NopHooks) OnShutdownEnd(error) {}
+
+// WithHooks adds Hooks to observe the lifecycle of the Group.
+// Hooks are called in the order they are added.
+func WithHooks(hooks ...Hooks) GroupOption {
+	return groupOptionFunc(func(o *groupOptions) {
+		o.hooks = append(o.hooks, hooks...)
+	})
+}
+
+// EventType is the type of a lifecycle Event. Each type matches a method of Hooks.
+type EventType int
+
+const (
+	// EventStart matches Hooks.OnStart.
+	EventStart EventType = iota + 1
+	// EventReady matches Hooks.OnReady.
+	EventReady
+	// EventStop matches Hooks.OnStop.
+	EventStop
+	// EventError matches Hooks.OnError.
+	EventError
+	// EventRestart matches Hooks.OnRestart.
+	EventRestart
+	// EventShutdownBegin matches Hooks.OnShutdownBegin.
+	EventShutdownBegin
+	// EventShutdownEnd matches Hooks.OnShutdownEnd.
+	EventShutdownEnd
+	// EventStopping matches StoppingHooks.OnStopping.
+	EventStopping
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventStart:
+		return "start"
+	case EventReady:
+		return "ready"
+	case EventStop:
+		return "stop"
+	case EventError:
+		return "error"
+	case EventRestart:
+		return "restart"
+	case EventShutdownBegin:
+		return "shutdown-begin"
+	case EventShutdownEnd:
+		return "shutdown-end"
+	case EventStopping:
+		return "stopping"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// Event is a lifecycle event of a Group (see Group.Subscribe).
+type Event struct {
+	Type EventType
+	// Name is the name of the Runnable (see Named), empty for the shutdown events.
+	Name string
+	// Time is when the event occurred.
+	Time time.Time
+	// Err is the error of the event, if any.
+	Err error
+}
+
+// observer dispatches lifecycle events to the Hooks and the subscribers of a Group.
+type observer struct {
+	hooks []Hooks
+
+	mu   sync.Mutex
+	subs map[chan Event]struct{}
+}
+
+func (o *observer) notify(ev Event) {
+	ev.Time = time.Now()
+	for _, h := range o.hooks {
+		switch ev.Type {
+		case EventStart:
+			h.OnStart(ev.Name)
+		case EventReady:
+			h.OnReady(ev.Name)
+		case EventStop:
+			h.OnStop(ev.Name, ev.Err)
+		case EventError:
+			h.OnError(ev.Name, ev.Err)
+		case EventRestart:
+			h.OnRestart(ev.Name, ev.Err)
+		case EventShutdownBegin:
+			h.OnShutdownBegin()
+		case EventShutdownEnd:
+			h.OnShutdownEnd(ev.Err)
+		case EventStopping:
+			if sh, ok := h.(StoppingHooks); ok {
+				sh.OnStopping(ev.Name)
+			}
+		}
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for ch := range o.subs {
+		select {
+		case ch <- ev:
+		default: // the subscriber is too slow, the event is dropped
+		}
+	}
+}
+
+func (o *observer) subscribe(buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.subs == nil {
+		o.subs = make(map[chan Event]struct{})
+	}
+	o.subs[ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			o.mu.Lock()
+			defer o.mu.Unlock()
+			delete(o.subs, ch)
+			close(ch)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package runy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingHooks records the calls as "<method> <name> <error>" strings.
+type recordingHooks struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (h *recordingHooks) record(format string, args ...any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = append(h.calls, fmt.Sprintf(format, args...))
+}
+
+func (h *recordingHooks) get() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.calls...)
+}
+
+func (h *recordingHooks) OnStart(name string) { h.record("start %s", name) }
+
+func (h *recordingHooks) OnReady(name string) { h.record("ready %s", name) }
+
+func (h *recordingHooks) OnStop(name string, err error) { h.record("stop %s %v", name, err != nil) }
+
+func (h *recordingHooks) OnError(name string, err error) { h.record("error %s", name) }
+
+func (h *recordingHooks) OnRestart(name string, err error) {
+	h.record("restart %s %v", name, err != nil)
+}
+
+func (h *recordingHooks) OnStopping(name string) { h.record("stopping %s", name) }
+
+func (h *recordingHooks) OnShutdownBegin() { h.record("shutdown-begin") }
+
+func (h *recordingHooks) OnShutdownEnd(err error) { h.record("shutdown-end %v", err != nil) }
+
+func TestHooks(t *testing.T) {
+	t.Run("lifecycle", func(t *testing.T) {
+		h := &recordingHooks{}
+		g := NewGroup(WithHooks(h))
+
+		rn := newReadyRunnable()
+		close(rn.ready)
+		g.Add(Named("db", rn))
+		g.NextPhase()
+		g.Add(Named("http", RunnableFunc(func(ctx context.Context) error { return assert.AnError })))
+
+		assert.ErrorIs(t, g.Start(context.Background()), assert.AnError)
+		assert.Equal(t, []string{
+			"start db",
+			"ready db",
+			"start http",
+			"error http",
+			"stop http true",
+			"shutdown-begin",
+			"stopping db",
+			"stop db false",
+			"shutdown-end true",
+		}, h.get())
+	})
+
+	t.Run("restarts", func(t *testing.T) {
+		h := &recordingHooks{}
+		backoff := WithBackoff(Backoff{Initial: time.Millisecond})
+		g := NewGroup(WithHooks(h), WithStrategy(OneForOne, backoff))
+
+		var strategyStarts, superviseStarts int
+		g.Add(Named("strategy", RunnableFunc(func(ctx context.Context) error {
+			if strategyStarts++; strategyStarts < 2 {
+				return assert.AnError
+			}
+			return nil
+		})))
+		g.NextPhase()
+		g.Add(Named("supervise", Supervise(RunnableFunc(func(ctx context.Context) error {
+			if superviseStarts++; superviseStarts < 2 {
+				return assert.AnError
+			}
+			return nil
+		}), backoff)))
+
+		assert.NoError(t, g.Start(context.Background()))
+		assert.Equal(t, []string{
+			"start strategy",
+			"error strategy",
+			"stop strategy true",
+			"restart strategy true",
+			"start strategy",
+			"stop strategy false",
+			"start supervise",
+			"restart supervise true",
+			"stop supervise false",
+			"shutdown-begin",
+			"shutdown-end false",
+		}, h.get())
+	})
+
+	t.Run("abandoned", func(t *testing.T) {
+		h := &recordingHooks{}
+		g := NewGroup(WithHooks(h))
+
+		release := make(chan struct{})
+		defer close(release)
+		g.Add(Named("stuck", StopTimeout(RunnableFunc(func(ctx context.Context) error {
+			<-release
+			return nil
+		}), 10*time.Millisecond)))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, g.Start(ctx), ErrAbandoned)
+		assert.Equal(t, []string{
+			"start stuck",
+			"shutdown-begin",
+			"stopping stuck",
+			"error stuck",
+			"shutdown-end true",
+		}, h.get())
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Run("events", func(t *testing.T) {
+		setupTest(t)
+
+		events, unsubscribe := Subscribe(16)
+		AddF(func(ctx context.Context) error { return nil })
+		assert.NoError(t, Start(context.Background()))
+		unsubscribe()
+		unsubscribe() // doesn't panic on the second call
+
+		var types []EventType
+		for ev := range events {
+			assert.False(t, ev.Time.IsZero())
+			types = append(types, ev.Type)
+		}
+		assert.Equal(t, []EventType{EventStart, EventStop, EventShutdownBegin, EventShutdownEnd}, types)
+	})
+
+	t.Run("slow subscriber", func(t *testing.T) {
+		setupTest(t)
+
+		events, unsubscribe := Subscribe(1)
+		defer unsubscribe()
+		AddF(func(ctx context.Context) error { return nil })
+		assert.NoError(t, Start(context.Background()))
+		assert.Len(t, events, 1)
+		assert.Equal(t, EventStart, (<-events).Type)
+	})
+}
+
+func TestEventType_String(t *testing.T) {
+	assert.Equal(t, "start", EventStart.String())
+	assert.Equal(t, "ready", EventReady.String())
+	assert.Equal(t, "stop", EventStop.String())
+	assert.Equal(t, "error", EventError.String())
+	assert.Equal(t, "restart", EventRestart.String())
+	assert.Equal(t, "shutdown-begin", EventShutdownBegin.String())
+	assert.Equal(t, "shutdown-end", EventShutdownEnd.String())
+	assert.Equal(t, "stopping", EventStopping.String())
+	assert.Equal(t, "EventType(42)", EventType(42).String())
+}
@@ -2,6 +2,9 @@ package runy
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
 )
 
 // Runnable allows a component to be started.
@@ -22,6 +25,16 @@ func (r RunnableFunc) Start(ctx context.Context) error {
 	return r(ctx)
 }
 
+// ReadyNotifier is an optional interface that a Runnable can implement to report that it's ready,
+// e.g. a server is listening or a cache is warmed up. See ReadySignal for a ready-made implementation.
+// A Group doesn't start the next phase until every Runnable of the current phase is ready or has finished,
+// and Group.WaitReady waits until every Runnable is.
+type ReadyNotifier interface {
+	// Ready returns a channel that's closed once the component is ready.
+	// It's called each time the component is started by a Group.
+	Ready() <-chan struct{}
+}
+
 // SugaredRunnable represents a simplified (sugared) version of Runnable with separate
 // Start and Stop methods instead of a single blocking Start method.
 // This allows for more explicit control over the lifecycle of a component.
@@ -71,32 +84,277 @@ func (f *sugaredFromFuncs) Stop(ctx context.Context) error {
 // FromSugared converts a SugaredRunnable into a standard Runnable.
 // The returned Runnable will run the Start method of the SugaredRunnable
 // and will call the Stop method when the context is canceled.
+// By default, Stop gets the canceled context, so it should return promptly.
+// WithStopTimeout and WithStopContext give it a fresh context instead, e.g. to drain connections.
+// Once Stop returns, the Runnable waits for Start to return (see WithJoinTimeout)
+// and returns the errors of both Stop and Start.
+// If the SugaredRunnable is a ReadyNotifier, so is the returned Runnable.
 // This allows SugaredRunnable implementations to be used anywhere a Runnable is required.
 func FromSugared(rn SugaredRunnable, opts ...FromSugaredOption) Runnable {
 	o := defaultOptions()
 	for _, opt := range opts {
-		opt(&o)
+		opt.applyFromSugared(&o)
+	}
+	run := fromSugared(rn, o)
+	if rd, ok := rn.(ReadyNotifier); ok {
+		return &readyRunnableFunc{RunnableFunc: run, ReadyNotifier: rd}
+	}
+	return run
+}
+
+// FromStartStop converts a SugaredRunnable whose Start method doesn't block into a standard Runnable,
+// e.g. a scheduler or a client with background goroutines. The returned Runnable runs Start,
+// then blocks until the context is canceled and calls Stop. Stop gets its context
+// like with FromSugared, WithStopTimeout and WithStopContext apply to it.
+// The returned Runnable is a ReadyNotifier that is ready once Start has returned nil,
+// again on each restart (see Supervise and WithStrategy).
+func FromStartStop(rn SugaredRunnable, opts ...FromSugaredOption) Runnable {
+	return newStartStop(rn, opts)
+}
+
+func newStartStop(rn SugaredRunnable, opts []FromSugaredOption) *startStopRunnable {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt.applyFromSugared(&o)
+	}
+	return &startStopRunnable{rn: rn, opts: o}
+}
+
+type startStopRunnable struct {
+	rn      SugaredRunnable
+	opts    fromSugaredOptions
+	cleanup bool // created by FromCleanup, not stopped by strategy restarts
+
+	mu    sync.Mutex
+	ready chan struct{} // closed once Start of the current run has returned nil
+}
+
+func (r *startStopRunnable) Start(ctx context.Context) error {
+	// A fresh channel is armed when Start returns rather than when it begins,
+	// as a Group may call Ready before the restarted Start begins. A channel that hasn't been closed
+	// is kept, as a Group calls Ready once and keeps watching it while Supervise retries Start.
+	defer func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		select {
+		case <-r.readyLocked():
+			r.ready = make(chan struct{})
+		default:
+		}
+	}()
+
+	if err := r.rn.Start(ctx); err != nil {
+		return err
 	}
+	r.mu.Lock()
+	close(r.readyLocked())
+	r.mu.Unlock()
+	<-ctx.Done()
+	return r.opts.stop(ctx, r.rn)
+}
+
+func (r *startStopRunnable) Ready() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.readyLocked()
+}
+
+func (r *startStopRunnable) readyLocked() chan struct{} {
+	if r.ready == nil {
+		r.ready = make(chan struct{})
+	}
+	return r.ready
+}
+
+type readyRunnableFunc struct {
+	RunnableFunc
+	ReadyNotifier
+}
+
+func fromSugared(rn SugaredRunnable, o fromSugaredOptions) RunnableFunc {
 	return RunnableFunc(func(ctx context.Context) error {
 		errCh := make(chan error, 1)
+		panicCh := make(chan *PanicError, 1)
 		go func() {
+			defer func() {
+				// Re-panic in the caller goroutine, so that the panic can be recovered by the Group.
+				if p := recover(); p != nil {
+					panicCh <- newPanicError(p)
+				}
+			}()
 			errCh <- rn.Start(ctx)
 		}()
 		select {
 		case <-ctx.Done():
-			return rn.Stop(ctx)
 		case err := <-errCh:
-			return err
+			if err != nil || ctx.Err() != nil {
+				return err
+			}
+			return o.exitEarly(ctx, rn)
+		case pErr := <-panicCh:
+			panic(pErr)
+		}
+
+		stopErr := o.stop(ctx, rn)
+		var timeout <-chan time.Time
+		if o.joinTimeout > 0 {
+			t := time.NewTimer(o.joinTimeout)
+			defer t.Stop()
+			timeout = t.C
+		}
+		select {
+		case err := <-errCh:
+			return joinErrors(stopErr, err)
+		case pErr := <-panicCh:
+			panic(pErr)
+		case <-timeout:
+			return joinErrors(stopErr, fmt.Errorf("%w: start didn't return within %s after stop", ErrAbandoned, o.joinTimeout))
 		}
 	})
 }
 
 type fromSugaredOptions struct {
+	logger      Logger
+	freshStop   bool // Stop gets a fresh context instead of the canceled one
+	stopTimeout time.Duration
+	stopCtx     func() context.Context
+	joinTimeout time.Duration
+	earlyExit   EarlyExit
 }
 
 func defaultOptions() fromSugaredOptions {
-	return fromSugaredOptions{}
+	return fromSugaredOptions{joinTimeout: 5 * time.Second}
+}
+
+// exitEarly handles Start returning nil before ctx is canceled.
+func (o fromSugaredOptions) exitEarly(ctx context.Context, rn SugaredRunnable) error {
+	switch o.earlyExit {
+	case EarlyExitError:
+		return ErrUnexpectedExit
+	case EarlyExitStopGroup:
+		return ErrStopGroup
+	case EarlyExitCleanup:
+		return o.stop(ctx, rn)
+	default:
+		return nil
+	}
+}
+
+// stop calls the Stop method of the SugaredRunnable with the canceled ctx,
+// or with a fresh stop context if WithStopTimeout or WithStopContext is set.
+func (o fromSugaredOptions) stop(ctx context.Context, rn SugaredRunnable) error {
+	// The name the Group knows the Runnable by, so that the records can be correlated with its records.
+	// It's looked up before the stop context replaces ctx, as WithStopContext may drop the values.
+	name, ok := nameFrom(ctx)
+	if !ok && o.logger != nil {
+		name = fmt.Sprintf("%T", rn)
+	}
+	if o.freshStop {
+		parent := detach(ctx)
+		if o.stopCtx != nil {
+			parent = o.stopCtx()
+		}
+		var cancel context.CancelFunc
+		if o.stopTimeout > 0 {
+			ctx, cancel = context.WithTimeout(parent, o.stopTimeout)
+		} else {
+			ctx, cancel = context.WithCancel(parent)
+		}
+		defer cancel()
+	}
+
+	if o.logger == nil {
+		return rn.Stop(ctx)
+	}
+	o.logger.Info("calling stop", "name", name)
+	begin := time.Now()
+	err := rn.Stop(ctx)
+	if err != nil {
+		o.logger.Error("stop returned", "name", name, "duration", time.Since(begin), "error", err)
+		return err
+	}
+	o.logger.Info("stop returned", "name", name, "duration", time.Since(begin))
+	return nil
+}
+
+// FromSugaredOption modifies the behavior of FromSugared.
+type FromSugaredOption interface {
+	applyFromSugared(o *fromSugaredOptions)
+}
+
+// fromSugaredOptionFunc is a FromSugaredOption implemented by a function.
+type fromSugaredOptionFunc func(o *fromSugaredOptions)
+
+func (f fromSugaredOptionFunc) applyFromSugared(o *fromSugaredOptions) {
+	f(o)
 }
 
-// FromSugaredOption is a function that modifies the behavior of FromSugared.
-type FromSugaredOption func(o *fromSugaredOptions)
+// WithStopTimeout passes a fresh context bounded with the timeout to the Stop method
+// instead of the canceled one, e.g. to let an http.Server drain its connections.
+// The context keeps the values of the canceled one (see WithStopContext).
+// A non-positive timeout means no bound, so Stop must return on its own.
+func WithStopTimeout(timeout time.Duration) FromSugaredOption {
+	return fromSugaredOptionFunc(func(o *fromSugaredOptions) {
+		o.freshStop = true
+		o.stopTimeout = timeout
+	})
+}
+
+// WithJoinTimeout bounds the wait for the Start method to return once the Stop method has returned.
+// If Start doesn't return in time, an error wrapping ErrAbandoned is returned, and the goroutine running Start
+// is left behind. It's 5 seconds by default. A non-positive timeout means waiting indefinitely.
+func WithJoinTimeout(timeout time.Duration) FromSugaredOption {
+	return fromSugaredOptionFunc(func(o *fromSugaredOptions) {
+		o.joinTimeout = timeout
+	})
+}
+
+// EarlyExit decides what a Runnable created by FromSugared does when Start returns nil
+// before the context is canceled, e.g. because a server has been closed.
+type EarlyExit int
+
+const (
+	// EarlyExitReturn returns nil, so a Group keeps running the other Runnables. It's the default.
+	EarlyExitReturn EarlyExit = iota
+	// EarlyExitError returns ErrUnexpectedExit, so a Group fails and stops.
+	EarlyExitError
+	// EarlyExitStopGroup returns ErrStopGroup, so a Group stops without reporting an error.
+	// It isn't restarted by a Strategy or Supervise.
+	EarlyExitStopGroup
+	// EarlyExitCleanup calls Stop to clean up and returns its error.
+	EarlyExitCleanup
+)
+
+func (e EarlyExit) String() string {
+	switch e {
+	case EarlyExitReturn:
+		return "return"
+	case EarlyExitError:
+		return "error"
+	case EarlyExitStopGroup:
+		return "stop-group"
+	case EarlyExitCleanup:
+		return "cleanup"
+	default:
+		return fmt.Sprintf("EarlyExit(%d)", int(e))
+	}
+}
+
+// WithEarlyExit sets what happens when Start returns nil before the context is canceled.
+// It's EarlyExitReturn by default.
+func WithEarlyExit(e EarlyExit) FromSugaredOption {
+	return fromSugaredOptionFunc(func(o *fromSugaredOptions) {
+		o.earlyExit = e
+	})
+}
+
+// WithStopContext sets a function returning the parent of the fresh context passed to the Stop method
+// instead of the canceled one. It's called when Stop is about to be called. Without it, the parent
+// of the fresh context set by WithStopTimeout is the context passed to Start without its cancellation.
+// The fresh context is bounded only by WithStopTimeout, if set.
+func WithStopContext(fn func() context.Context) FromSugaredOption {
+	return fromSugaredOptionFunc(func(o *fromSugaredOptions) {
+		o.freshStop = true
+		o.stopCtx = fn
+	})
+}
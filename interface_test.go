@@ -0,0 +1,340 @@
+package runy
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+type ctxKey struct{}
+
+func TestFromSugared(t *testing.T) {
+	// stopCtx returns a Runnable whose Stop sends its context to the channel.
+	// Stop fails if its context is done.
+	stopCtx := func(opts ...FromSugaredOption) (Runnable, chan context.Context) {
+		ctxCh := make(chan context.Context, 1)
+		rn := FromSugared(SugaredFromFuncs(
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			},
+			func(ctx context.Context) error {
+				ctxCh <- ctx
+				return ctx.Err()
+			},
+		), opts...)
+		return rn, ctxCh
+	}
+
+	t.Run("canceled stop context", func(t *testing.T) {
+		rn, ctxCh := stopCtx()
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+		cancel()
+		assert.ErrorIs(t, rn.Start(ctx), context.Canceled)
+
+		stopCtx := <-ctxCh
+		assert.Equal(t, "value", stopCtx.Value(ctxKey{}))
+	})
+
+	t.Run("fresh stop context", func(t *testing.T) {
+		rn, ctxCh := stopCtx(WithStopTimeout(0))
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+		cancel()
+		assert.NoError(t, rn.Start(ctx))
+
+		stopCtx := <-ctxCh
+		assert.Equal(t, "value", stopCtx.Value(ctxKey{}))
+		_, ok := stopCtx.Deadline()
+		assert.False(t, ok)
+	})
+
+	t.Run("stop timeout", func(t *testing.T) {
+		rn, ctxCh := stopCtx(WithStopTimeout(time.Minute))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, rn.Start(ctx))
+
+		deadline, ok := (<-ctxCh).Deadline()
+		if assert.True(t, ok) {
+			assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+		}
+	})
+
+	t.Run("stop context", func(t *testing.T) {
+		rn, ctxCh := stopCtx(
+			WithStopContext(func() context.Context {
+				return context.WithValue(context.Background(), ctxKey{}, "stop")
+			}),
+			WithStopTimeout(time.Minute),
+		)
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "start"))
+		cancel()
+		assert.NoError(t, rn.Start(ctx))
+
+		stopCtx := <-ctxCh
+		assert.Equal(t, "stop", stopCtx.Value(ctxKey{}))
+		_, ok := stopCtx.Deadline()
+		assert.True(t, ok)
+	})
+
+	t.Run("stop context is canceled after stop", func(t *testing.T) {
+		rn, ctxCh := stopCtx(WithStopTimeout(time.Minute))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, rn.Start(ctx))
+		assert.Error(t, (<-ctxCh).Err())
+	})
+}
+
+func TestFromSugared_Join(t *testing.T) {
+	t.Run("waits for start", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+
+		var returned atomic.Bool
+		rn := FromSugared(SugaredFromFuncs(
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				time.Sleep(20 * time.Millisecond)
+				returned.Store(true)
+				return nil
+			},
+			func(ctx context.Context) error { return nil },
+		))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, rn.Start(ctx))
+		assert.True(t, returned.Load())
+	})
+
+	t.Run("merges errors", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+
+		startErr, stopErr := errors.New("start"), errors.New("stop")
+		rn := FromSugared(SugaredFromFuncs(
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				return startErr
+			},
+			func(ctx context.Context) error { return stopErr },
+		))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := rn.Start(ctx)
+		assert.ErrorIs(t, err, startErr)
+		assert.ErrorIs(t, err, stopErr)
+	})
+
+	t.Run("single error as is", func(t *testing.T) {
+		rn := FromSugared(SugaredFromFuncs(
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				return assert.AnError
+			},
+			nil,
+		))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.Equal(t, assert.AnError, rn.Start(ctx))
+	})
+
+	t.Run("join timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		rn := FromSugared(SugaredFromFuncs(
+			func(ctx context.Context) error {
+				<-release
+				return nil
+			},
+			nil,
+		), WithJoinTimeout(10*time.Millisecond))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, rn.Start(ctx), ErrAbandoned)
+
+		close(release)
+		goleak.VerifyNone(t)
+	})
+
+	t.Run("panic after stop", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+
+		rn := FromSugared(SugaredFromFuncs(
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				panic("boom")
+			},
+			nil,
+		))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		g := NewGroup()
+		g.Add(rn)
+		var pErr *PanicError
+		assert.ErrorAs(t, g.Start(ctx), &pErr)
+	})
+}
+
+func TestFromSugared_EarlyExit(t *testing.T) {
+	// group returns a Group with a Runnable created by FromSugared whose Start returns nil right away,
+	// and a Runnable that runs until the Group is stopped.
+	group := func(stop StopFunc, opts ...FromSugaredOption) Group {
+		g := NewGroup()
+		g.Add(FromSugared(SugaredFromFuncs(nil, stop), opts...))
+		g.AddF(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+		return g
+	}
+
+	t.Run("return", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		assert.NoError(t, group(nil).Start(ctx))
+		assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded, "the group kept running")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := group(nil, WithEarlyExit(EarlyExitError)).Start(context.Background())
+		assert.ErrorIs(t, err, ErrUnexpectedExit)
+	})
+
+	t.Run("stop group", func(t *testing.T) {
+		assert.NoError(t, group(nil, WithEarlyExit(EarlyExitStopGroup)).Start(context.Background()))
+	})
+
+	t.Run("stop group outside group", func(t *testing.T) {
+		rn := FromSugared(SugaredFromFuncs(nil, nil), WithEarlyExit(EarlyExitStopGroup))
+		assert.ErrorIs(t, rn.Start(context.Background()), ErrStopGroup)
+	})
+
+	t.Run("stop group with strategy", func(t *testing.T) {
+		g := NewGroup(WithStrategy(OneForOne, WithRestartPolicy(RestartAlways)))
+		g.Add(Supervise(FromSugared(SugaredFromFuncs(nil, nil), WithEarlyExit(EarlyExitStopGroup)),
+			WithRestartPolicy(RestartAlways)))
+		assert.NoError(t, g.Start(context.Background()))
+	})
+
+	t.Run("cleanup", func(t *testing.T) {
+		var stopped bool
+		rn := FromSugared(SugaredFromFuncs(nil, func(ctx context.Context) error {
+			stopped = true
+			return assert.AnError
+		}), WithEarlyExit(EarlyExitCleanup))
+		assert.ErrorIs(t, rn.Start(context.Background()), assert.AnError)
+		assert.True(t, stopped)
+	})
+}
+
+func TestEarlyExit_String(t *testing.T) {
+	assert.Equal(t, "return", EarlyExitReturn.String())
+	assert.Equal(t, "error", EarlyExitError.String())
+	assert.Equal(t, "stop-group", EarlyExitStopGroup.String())
+	assert.Equal(t, "cleanup", EarlyExitCleanup.String())
+	assert.Equal(t, "EarlyExit(42)", EarlyExit(42).String())
+}
+
+func TestFromStartStop(t *testing.T) {
+	t.Run("runs until canceled", func(t *testing.T) {
+		var started, stopped atomic.Bool
+		rn := FromStartStop(SugaredFromFuncs(
+			func(ctx context.Context) error {
+				started.Store(true)
+				return nil
+			},
+			func(ctx context.Context) error {
+				assert.NoError(t, ctx.Err())
+				stopped.Store(true)
+				return assert.AnError
+			},
+		), WithStopTimeout(time.Minute))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() { errCh <- rn.Start(ctx) }()
+
+		rd, ok := rn.(ReadyNotifier)
+		if assert.True(t, ok) {
+			select {
+			case <-rd.Ready():
+			case <-time.After(time.Second):
+				assert.Fail(t, "not ready after start returned")
+			}
+		}
+		assert.True(t, started.Load())
+		assert.False(t, stopped.Load())
+
+		cancel()
+		assert.ErrorIs(t, <-errCh, assert.AnError)
+		assert.True(t, stopped.Load())
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		rn := FromStartStop(SugaredFromFuncs(nil, nil))
+		rd := rn.(ReadyNotifier)
+		for i := 0; i < 2; i++ {
+			ready := rd.Ready()
+			select {
+			case <-ready:
+				assert.Fail(t, "ready before start", "run %d", i)
+			default:
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errCh := make(chan error, 1)
+			go func() { errCh <- rn.Start(ctx) }()
+			select {
+			case <-ready:
+			case <-time.After(time.Second):
+				assert.Fail(t, "not ready after start returned", "run %d", i)
+			}
+			cancel()
+			assert.NoError(t, <-errCh)
+		}
+	})
+
+	t.Run("supervised start error", func(t *testing.T) {
+		var starts int
+		g := NewGroup()
+		g.Add(Supervise(FromStartStop(SugaredFromFuncs(func(ctx context.Context) error {
+			if starts++; starts == 1 {
+				return assert.AnError
+			}
+			return nil
+		}, nil)), WithBackoff(Backoff{})))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		errCh := make(chan error, 1)
+		go func() { errCh <- g.Start(ctx) }()
+		readyCtx, readyCancel := context.WithTimeout(ctx, time.Second)
+		defer readyCancel()
+		assert.NoError(t, g.WaitReady(readyCtx))
+
+		cancel()
+		assert.NoError(t, <-errCh)
+		assert.Equal(t, 2, starts)
+	})
+
+	t.Run("start error", func(t *testing.T) {
+		var stopped bool
+		rn := FromStartStop(SugaredFromFuncs(
+			func(ctx context.Context) error { return assert.AnError },
+			func(ctx context.Context) error {
+				stopped = true
+				return nil
+			},
+		))
+		assert.ErrorIs(t, rn.Start(context.Background()), assert.AnError)
+		assert.False(t, stopped)
+		select {
+		case <-rn.(ReadyNotifier).Ready():
+			assert.Fail(t, "ready after start failed")
+		default:
+		}
+	})
+}
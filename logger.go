@@ -0,0 +1,96 @@
+package runy
+
+import (
+	"time"
+)
+
+// Logger is a structured logger the lifecycle records are written to.
+// The args are alternating keys and values, as in log/slog, so *slog.Logger implements Logger
+// (see WithLogger). Other loggers can be plugged in with a small adapter, e.g. for zap:
+//
+//	type zapLogger struct{ l *zap.SugaredLogger }
+//
+//	func (z zapLogger) Debug(msg string, args ...any) { z.l.Debugw(msg, args...) }
+//	func (z zapLogger) Info(msg string, args ...any)  { z.l.Infow(msg, args...) }
+//	func (z zapLogger) Warn(msg string, args ...any)  { z.l.Warnw(msg, args...) }
+//	func (z zapLogger) Error(msg string, args ...any) { z.l.Errorw(msg, args...) }
+//
+//	runy.NewGroup(runy.WithLogAdapter(zapLogger{l: zap.S()}))
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// LoggerOption sets the Logger of a Group or of FromSugared.
+// It's both a GroupOption and a FromSugaredOption.
+type LoggerOption struct {
+	logger Logger
+}
+
+func (o LoggerOption) applyGroup(opts *groupOptions) {
+	opts.logger = o.logger
+}
+
+func (o LoggerOption) applyFromSugared(opts *fromSugaredOptions) {
+	opts.logger = o.logger
+}
+
+// WithLogAdapter sets the Logger the lifecycle records are written to. Nothing is logged by default.
+// A Group logs when Runnables are registered, started, ready, stopping, stopped, failed and restarted,
+// and when the shutdown begins and ends, along with its duration.
+// FromSugared logs when Stop is called and when it returns, under the name of the Runnable in the Group (see Named).
+func WithLogAdapter(logger Logger) LoggerOption {
+	return LoggerOption{logger: logger}
+}
+
+var _ StoppingHooks = (*logHooks)(nil)
+
+// logHooks writes the lifecycle records of a Group to a Logger.
+type logHooks struct {
+	l Logger
+	// shutdownBegin is accessed only by the goroutine running Group.Start.
+	shutdownBegin time.Time
+}
+
+func (h *logHooks) OnStart(name string) {
+	h.l.Info("runnable started", "name", name)
+}
+
+func (h *logHooks) OnReady(name string) {
+	h.l.Info("runnable ready", "name", name)
+}
+
+func (h *logHooks) OnStopping(name string) {
+	h.l.Info("runnable stopping", "name", name)
+}
+
+func (h *logHooks) OnStop(name string, err error) {
+	h.l.Info("runnable stopped", "name", name)
+}
+
+func (h *logHooks) OnError(name string, err error) {
+	h.l.Error("runnable failed", "name", name, "error", err)
+}
+
+func (h *logHooks) OnRestart(name string, err error) {
+	if err != nil {
+		h.l.Warn("runnable restarting", "name", name, "error", err)
+		return
+	}
+	h.l.Warn("runnable restarting", "name", name)
+}
+
+func (h *logHooks) OnShutdownBegin() {
+	h.shutdownBegin = time.Now()
+	h.l.Info("shutdown started")
+}
+
+func (h *logHooks) OnShutdownEnd(err error) {
+	if err != nil {
+		h.l.Error("shutdown completed", "duration", time.Since(h.shutdownBegin), "error", err)
+		return
+	}
+	h.l.Info("shutdown completed", "duration", time.Since(h.shutdownBegin))
+}
@@ -0,0 +1,14 @@
+//go:build go1.21
+
+package runy
+
+import (
+	"log/slog"
+)
+
+var _ Logger = (*slog.Logger)(nil)
+
+// WithLogger sets the *slog.Logger the lifecycle records are written to. See WithLogAdapter.
+func WithLogger(logger *slog.Logger) LoggerOption {
+	return WithLogAdapter(logger)
+}
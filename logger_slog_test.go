@@ -0,0 +1,24 @@
+//go:build go1.21
+
+package runy
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	g := NewGroup(WithLogger(logger))
+	g.SAdd(SugaredFromFuncs(nil, nil), WithLogger(logger))
+	assert.NoError(t, g.Start(context.Background()))
+	assert.Contains(t, buf.String(), `level=DEBUG msg="runnable registered" name=runy.RunnableFunc#0 phase=0`)
+	assert.Contains(t, buf.String(), `level=INFO msg="runnable started" name=runy.RunnableFunc#0`)
+	assert.Contains(t, buf.String(), `level=INFO msg="shutdown completed" duration=`)
+}
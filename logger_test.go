@@ -0,0 +1,120 @@
+package runy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingLogger records the messages as "<level> <msg>" strings.
+type recordingLogger struct {
+	mu      sync.Mutex
+	records []string
+	args    [][]any
+}
+
+func (l *recordingLogger) log(level, msg string, args []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, fmt.Sprintf("%s %s", level, msg))
+	l.args = append(l.args, args)
+}
+
+func (l *recordingLogger) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.records...)
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) { l.log("DEBUG", msg, args) }
+
+func (l *recordingLogger) Info(msg string, args ...any) { l.log("INFO", msg, args) }
+
+func (l *recordingLogger) Warn(msg string, args ...any) { l.log("WARN", msg, args) }
+
+func (l *recordingLogger) Error(msg string, args ...any) { l.log("ERROR", msg, args) }
+
+func TestLogger(t *testing.T) {
+	t.Run("group", func(t *testing.T) {
+		l := &recordingLogger{}
+		g := NewGroup(WithLogAdapter(l))
+
+		rn := newReadyRunnable()
+		close(rn.ready)
+		g.Add(Named("db", rn))
+		g.NextPhase()
+		g.Add(Named("http", RunnableFunc(func(ctx context.Context) error { return assert.AnError })))
+
+		assert.ErrorIs(t, g.Start(context.Background()), assert.AnError)
+		assert.Equal(t, []string{
+			"DEBUG runnable registered",
+			"DEBUG runnable registered",
+			"INFO runnable started",
+			"INFO runnable ready",
+			"INFO runnable started",
+			"ERROR runnable failed",
+			"INFO runnable stopped",
+			"INFO shutdown started",
+			"INFO runnable stopping",
+			"INFO runnable stopped",
+			"ERROR shutdown completed",
+		}, l.get())
+		assert.Equal(t, []any{"name", "db", "phase", 0}, l.args[0])
+		assert.Equal(t, []any{"name", "db"}, l.args[2])
+		assert.Equal(t, "duration", l.args[10][0])
+	})
+
+	t.Run("sugared", func(t *testing.T) {
+		l := &recordingLogger{}
+		rn := FromSugared(SugaredFromFuncs(
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			},
+			func(ctx context.Context) error { return assert.AnError },
+		), WithLogAdapter(l))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, rn.Start(ctx), assert.AnError)
+		assert.Equal(t, []string{"INFO calling stop", "ERROR stop returned"}, l.get())
+		assert.Equal(t, []any{"name", "*runy.sugaredFromFuncs"}, l.args[0])
+	})
+
+	t.Run("sugared in group", func(t *testing.T) {
+		l := &recordingLogger{}
+		g := NewGroup()
+		g.Add(Named("http", FromSugared(SugaredFromFuncs(
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			}, nil,
+		), WithLogAdapter(l))))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, g.Start(ctx))
+		assert.Equal(t, []string{"INFO calling stop", "INFO stop returned"}, l.get())
+		assert.Equal(t, []any{"name", "http"}, l.args[0])
+	})
+
+	t.Run("sugared in group with stop context", func(t *testing.T) {
+		l := &recordingLogger{}
+		g := NewGroup()
+		g.Add(Named("http", FromSugared(SugaredFromFuncs(
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			}, nil,
+		), WithStopContext(context.Background), WithLogAdapter(l))))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, g.Start(ctx))
+		assert.Equal(t, []string{"INFO calling stop", "INFO stop returned"}, l.get())
+		assert.Equal(t, []any{"name", "http"}, l.args[0])
+	})
+}
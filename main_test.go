@@ -11,7 +11,7 @@ func TestMain(m *testing.M) {
 		// signal_test.go
 		goleak.IgnoreAnyFunction("github.com/belo4ya/runy.(*Task).Run"),
 		goleak.IgnoreAnyFunction("github.com/belo4ya/runy.sendSignal"),
-		goleak.IgnoreAnyFunction("github.com/belo4ya/runy.SetupSignalHandler.func1"),
+		goleak.IgnoreAnyFunction("github.com/belo4ya/runy.setupSignalHandler.func1"),
 		goleak.IgnoreAnyFunction("github.com/belo4ya/runy.TestSetupSignalHandler.func1"),
 	)
 }
@@ -0,0 +1,156 @@
+package runy
+
+import (
+	"sync"
+	"time"
+)
+
+// Metrics records lifecycle metrics of a Group (see WithMetrics).
+// The methods are called synchronously, possibly from different goroutines,
+// so they must be safe for concurrent use and return quickly.
+// See the github.com/belo4ya/runy/runyprom module for a Prometheus implementation
+// and MemoryMetrics for an in-memory one.
+type Metrics interface {
+	// SetUp is called with true when a Runnable is started and with false when it returns.
+	SetUp(name string, up bool)
+	// IncRestarts is called when a Runnable is restarted by the Strategy of the Group or by Supervise.
+	IncRestarts(name string)
+	// ObserveStartLatency is called with the time a ReadyNotifier took from its start until it was ready.
+	ObserveStartLatency(name string, d time.Duration)
+	// ObserveShutdownDuration is called with the time the Group took to stop its Runnables.
+	ObserveShutdownDuration(d time.Duration)
+}
+
+// WithMetrics sets the Metrics the lifecycle metrics of the Group are recorded to.
+func WithMetrics(m Metrics) GroupOption {
+	return groupOptionFunc(func(o *groupOptions) {
+		o.metrics = m
+	})
+}
+
+// metricsHooks records the lifecycle metrics of a Group to Metrics.
+type metricsHooks struct {
+	NopHooks
+	m Metrics
+
+	mu            sync.Mutex
+	startedAt     map[string]time.Time
+	shutdownBegin time.Time
+}
+
+func (h *metricsHooks) OnStart(name string) {
+	h.mu.Lock()
+	if h.startedAt == nil {
+		h.startedAt = make(map[string]time.Time)
+	}
+	h.startedAt[name] = time.Now()
+	h.mu.Unlock()
+	h.m.SetUp(name, true)
+}
+
+func (h *metricsHooks) OnReady(name string) {
+	h.mu.Lock()
+	startedAt, ok := h.startedAt[name]
+	h.mu.Unlock()
+	if ok {
+		h.m.ObserveStartLatency(name, time.Since(startedAt))
+	}
+}
+
+func (h *metricsHooks) OnStop(name string, _ error) {
+	h.m.SetUp(name, false)
+}
+
+func (h *metricsHooks) OnRestart(name string, _ error) {
+	h.m.IncRestarts(name)
+}
+
+func (h *metricsHooks) OnShutdownBegin() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.shutdownBegin = time.Now()
+}
+
+func (h *metricsHooks) OnShutdownEnd(_ error) {
+	h.mu.Lock()
+	d := time.Since(h.shutdownBegin)
+	h.mu.Unlock()
+	h.m.ObserveShutdownDuration(d)
+}
+
+var _ Metrics = (*MemoryMetrics)(nil)
+
+// MemoryMetrics is an in-memory implementation of Metrics, e.g. for tests.
+// The zero value is ready to use.
+type MemoryMetrics struct {
+	mu                sync.Mutex
+	up                map[string]bool
+	restarts          map[string]int
+	startLatencies    map[string][]time.Duration
+	shutdownDurations []time.Duration
+}
+
+// SetUp implements Metrics.
+func (m *MemoryMetrics) SetUp(name string, up bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.up == nil {
+		m.up = make(map[string]bool)
+	}
+	m.up[name] = up
+}
+
+// IncRestarts implements Metrics.
+func (m *MemoryMetrics) IncRestarts(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.restarts == nil {
+		m.restarts = make(map[string]int)
+	}
+	m.restarts[name]++
+}
+
+// ObserveStartLatency implements Metrics.
+func (m *MemoryMetrics) ObserveStartLatency(name string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.startLatencies == nil {
+		m.startLatencies = make(map[string][]time.Duration)
+	}
+	m.startLatencies[name] = append(m.startLatencies[name], d)
+}
+
+// ObserveShutdownDuration implements Metrics.
+func (m *MemoryMetrics) ObserveShutdownDuration(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shutdownDurations = append(m.shutdownDurations, d)
+}
+
+// Up reports whether the Runnable is up, i.e. has been started and hasn't returned.
+func (m *MemoryMetrics) Up(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.up[name]
+}
+
+// Restarts returns how many times the Runnable has been restarted.
+func (m *MemoryMetrics) Restarts(name string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.restarts[name]
+}
+
+// StartLatencies returns the observed start latencies of the Runnable.
+func (m *MemoryMetrics) StartLatencies(name string) []time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]time.Duration(nil), m.startLatencies[name]...)
+}
+
+// ShutdownDurations returns the observed shutdown durations.
+func (m *MemoryMetrics) ShutdownDurations() []time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]time.Duration(nil), m.shutdownDurations...)
+}
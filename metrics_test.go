@@ -0,0 +1,47 @@
+package runy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	m := &MemoryMetrics{}
+	g := NewGroup(WithMetrics(m), WithStrategy(OneForOne, WithBackoff(Backoff{Initial: time.Millisecond})))
+
+	rn := newReadyRunnable()
+	g.Add(Named("db", rn))
+	var starts int
+	g.Add(Named("worker", RunnableFunc(func(ctx context.Context) error {
+		if starts++; starts < 3 {
+			return assert.AnError
+		}
+		<-ctx.Done()
+		return nil
+	})))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() { errCh <- g.Start(ctx) }()
+
+	time.Sleep(10 * time.Millisecond)
+	close(rn.ready)
+	assert.NoError(t, g.WaitReady(ctx))
+	assert.Eventually(t, func() bool { return m.Restarts("worker") == 2 }, time.Second, time.Millisecond)
+	assert.True(t, m.Up("db"))
+	assert.True(t, m.Up("worker"))
+	if latencies := m.StartLatencies("db"); assert.Len(t, latencies, 1) {
+		assert.GreaterOrEqual(t, latencies[0], 10*time.Millisecond)
+	}
+	assert.Empty(t, m.StartLatencies("worker"))
+
+	cancel()
+	assert.NoError(t, <-errCh)
+	assert.False(t, m.Up("db"))
+	assert.False(t, m.Up("worker"))
+	assert.Len(t, m.ShutdownDurations(), 1)
+}
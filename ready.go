@@ -0,0 +1,54 @@
+package runy
+
+import (
+	"sync"
+)
+
+// ReadySignal helps to implement ReadyNotifier. Embed it into a Runnable
+// and call SignalReady once the Runnable is ready, e.g. a server has started listening:
+//
+//	type Server struct {
+//		runy.ReadySignal
+//		...
+//	}
+//
+//	func (s *Server) Start(ctx context.Context) error {
+//		lis, err := net.Listen("tcp", s.addr)
+//		if err != nil {
+//			return err
+//		}
+//		s.SignalReady()
+//		...
+//	}
+//
+// The zero value is ready to use. A ReadySignal must not be copied after first use.
+type ReadySignal struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+// Ready implements ReadyNotifier.
+func (s *ReadySignal) Ready() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chLocked()
+}
+
+// SignalReady closes the channel returned by Ready. Subsequent calls do nothing.
+func (s *ReadySignal) SignalReady() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := s.chLocked()
+	select {
+	case <-ch:
+	default:
+		close(ch)
+	}
+}
+
+func (s *ReadySignal) chLocked() chan struct{} {
+	if s.ch == nil {
+		s.ch = make(chan struct{})
+	}
+	return s.ch
+}
@@ -0,0 +1,26 @@
+package runy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadySignal(t *testing.T) {
+	var s ReadySignal
+	ready := s.Ready()
+	select {
+	case <-ready:
+		assert.Fail(t, "ready before SignalReady")
+	default:
+	}
+
+	s.SignalReady()
+	s.SignalReady() // doesn't panic on the second call
+	select {
+	case <-ready:
+	default:
+		assert.Fail(t, "not ready after SignalReady")
+	}
+	assert.Equal(t, ready, s.Ready())
+}
@@ -0,0 +1,691 @@
+package runy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// run is a single run of a Group, from Group.Start until it returns.
+// All its fields are accessed only by the goroutine running Group.Start.
+type run struct {
+	opts  groupOptions
+	units []*unit
+	obs   *observer
+
+	ctx      context.Context // parent context of the units
+	ready    chan struct{}   // closed once all the units are up, nil afterwards
+	events   chan event
+	pending  []event       // received events that are yet to be handled
+	requests chan func()   // functions to be executed by the loop
+	stopping chan struct{} // closed when the loop is over
+	done     chan struct{} // closed when the run is over
+	errs     errorCollector
+
+	running  int              // number of unit goroutines that haven't exited yet
+	restarts []time.Time      // restarts within the restart window of the strategy
+	watchdog <-chan time.Time // fires when the shutdown diagnostics are due, see WithShutdownWatchdog
+}
+
+// event is sent by a unit goroutine when the unit is ready or has exited.
+type event struct {
+	u         *unit
+	gen       int // generation of the unit the event belongs to
+	exited    bool
+	err       *RunnableError // error the unit exited with
+	stopGroup bool           // the unit asked to stop the Group, see ErrStopGroup
+}
+
+func newRun(opts groupOptions, units []*unit, ready chan struct{}, obs *observer) *run {
+	for _, u := range units {
+		u.recoverPanics = opts.recoverPanics
+	}
+	return &run{
+		opts:     opts,
+		units:    units,
+		obs:      obs,
+		ready:    ready,
+		events:   make(chan event),
+		requests: make(chan func()),
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (r *run) execute(ctx context.Context) error {
+	defer close(r.done)
+
+	// Units get contexts detached from ctx, so that each of them can be stopped separately.
+	r.ctx = detach(ctx)
+	r.warnDeps(r.units...)
+	r.loop(ctx.Done())
+	close(r.stopping)
+	r.obs.notify(Event{Type: EventShutdownBegin})
+	r.shutdown()
+	err := r.errs.close()
+	r.obs.notify(Event{Type: EventShutdownEnd, Err: err})
+	return err
+}
+
+// loop starts the units and handles their events until stop is closed,
+// all the units have exited or a unit fails without being restarted.
+func (r *run) loop(stop <-chan struct{}) {
+	r.startNext()
+	r.checkReady()
+	for r.running > 0 || len(r.pending) > 0 {
+		var ev event
+		if len(r.pending) > 0 {
+			ev, r.pending = r.pending[0], r.pending[1:]
+			if ev.gen != ev.u.gen {
+				continue
+			}
+		} else {
+			select {
+			case <-stop:
+				return
+			case fn := <-r.requests:
+				fn()
+				r.checkReady()
+				continue
+			case ev = <-r.events:
+				if !r.receive(ev) {
+					continue
+				}
+			}
+		}
+		if !r.handle(ev, stop) {
+			return
+		}
+		r.checkReady()
+	}
+}
+
+// checkReady closes the ready channel once all the units have been started
+// and the ones that are ReadyNotifiers are up.
+func (r *run) checkReady() {
+	if r.ready == nil {
+		return
+	}
+	for _, u := range r.units {
+		if !u.started || u.notifier && !u.up {
+			return
+		}
+	}
+	close(r.ready)
+	r.ready = nil
+}
+
+// warnDeps warns about the units that depend by name on Runnables that aren't ReadyNotifiers,
+// as they aren't started until those Runnables have finished.
+func (r *run) warnDeps(units ...*unit) {
+	if r.opts.logger == nil {
+		return
+	}
+	for _, u := range units {
+		for _, name := range u.waitsExit {
+			r.opts.logger.Warn("runnable depends on a runnable that isn't a ReadyNotifier, it starts only once the dependency has finished",
+				"name", u.name, "dependency", name)
+		}
+	}
+}
+
+// do executes fn in the loop. Returns ErrGroupStopping if the loop is over
+// or the error of ctx if it's done before the loop takes fn.
+func (r *run) do(ctx context.Context, fn func()) error {
+	select {
+	case r.requests <- fn:
+		return nil
+	case <-r.stopping:
+		return ErrGroupStopping
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// loopRequests returns the channel of the requests to the loop while it's running, nil afterwards,
+// so that the requests are also served while the loop waits for a unit to stop or for a restart backoff.
+func (r *run) loopRequests() <-chan func() {
+	select {
+	case <-r.stopping:
+		return nil
+	default:
+		return r.requests
+	}
+}
+
+// add starts a unit for the entry registered while the run is in progress.
+func (r *run) add(e *entry) error {
+	errCh := make(chan error, 1)
+	if err := r.do(context.Background(), func() {
+		u := newUnit(e)
+		u.recoverPanics = r.opts.recoverPanics
+		if err := link(u, r.units); err != nil {
+			errCh <- err
+			return
+		}
+		r.warnDeps(u)
+		u.index = len(r.units)
+		r.units = append(r.units, u)
+		r.startNext()
+		errCh <- nil
+	}); err != nil {
+		return err
+	}
+	return <-errCh
+}
+
+// remove stops the unit of the entry and removes it from the run.
+// Returns the error the unit exited with. The unit is removed by the loop, but waited for outside of it,
+// so that a unit that doesn't stop can't block the loop.
+func (r *run) remove(ctx context.Context, e *entry) error {
+	var u *unit
+	var x *exit // nil if the unit isn't running
+	removed := make(chan struct{})
+	err := r.do(ctx, func() {
+		defer close(removed)
+		for i, cand := range r.units {
+			if cand.entry != e {
+				continue
+			}
+			r.units = append(r.units[:i:i], r.units[i+1:]...)
+			for j := i; j < len(r.units); j++ {
+				r.units[j].index = j
+			}
+			u = cand
+			u.removed = true
+			if u.started && !u.exited {
+				x = u.exit
+				u.gen++ // the later events of the unit are dropped
+				u.cancel()
+				r.markStopping(u)
+			}
+			return
+		}
+	})
+	if err == ErrGroupStopping {
+		// The unit is stopped by the shutdown.
+		select {
+		case <-r.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err != nil {
+		return err
+	}
+	<-removed
+
+	switch {
+	case u == nil:
+		return nil
+	case x == nil:
+		if u.err != nil {
+			return u.err
+		}
+		return nil
+	}
+
+	var timeout <-chan time.Time
+	if u.stopTimeout > 0 {
+		t := time.NewTimer(u.stopTimeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+	select {
+	case <-x.done:
+		if x.err != nil {
+			u.entry.setStatus(func(s *RunnableStatus) {
+				s.State = RunnableFailed
+				s.LastError = x.err
+			})
+			r.obs.notify(Event{Type: EventError, Name: u.name, Err: x.err})
+			r.obs.notify(Event{Type: EventStop, Name: u.name, Err: x.err})
+			return x.err
+		}
+		u.entry.setStatus(func(s *RunnableStatus) { s.State = RunnableStopped })
+		r.obs.notify(Event{Type: EventStop, Name: u.name})
+		return nil
+	case <-timeout:
+		err := u.error(StageStop, fmt.Errorf("%w: not stopped within %s", ErrAbandoned, u.stopTimeout))
+		u.entry.setStatus(func(s *RunnableStatus) {
+			s.State = RunnableFailed
+			s.LastError = err
+		})
+		r.obs.notify(Event{Type: EventError, Name: u.name, Err: err})
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// receive updates the state of the unit the event belongs to.
+// Returns false if the event is stale, i.e. the unit has been restarted since.
+func (r *run) receive(ev event) bool {
+	if ev.exited {
+		r.running--
+	}
+	if ev.gen != ev.u.gen {
+		return false
+	}
+	ev.u.up = true
+	if !ev.exited {
+		ev.u.entry.setStatus(func(s *RunnableStatus) {
+			if s.State == RunnableStarting {
+				s.State = RunnableReady
+			}
+		})
+		r.obs.notify(Event{Type: EventReady, Name: ev.u.name})
+		return true
+	}
+	ev.u.exited = true
+	ev.u.err = ev.err
+	ev.u.entry.setStatus(func(s *RunnableStatus) {
+		s.State = RunnableStopped
+		if ev.err != nil {
+			s.State = RunnableFailed
+			s.LastError = ev.err
+		}
+	})
+	if ev.err != nil {
+		r.obs.notify(Event{Type: EventError, Name: ev.u.name, Err: ev.err})
+		r.obs.notify(Event{Type: EventStop, Name: ev.u.name, Err: ev.err})
+	} else {
+		r.obs.notify(Event{Type: EventStop, Name: ev.u.name})
+	}
+	return true
+}
+
+// handle reacts to a received event: starts the next units or restarts the exited unit
+// according to the strategy. Returns false if the run has failed.
+func (r *run) handle(ev event, stop <-chan struct{}) bool {
+	if !ev.exited {
+		r.startNext()
+		return true
+	}
+	if ev.stopGroup {
+		return false
+	}
+
+	var err error
+	if ev.err != nil {
+		err = ev.err
+	}
+	if r.opts.strategy == 0 || !r.opts.supervise.policy.shouldRestart(err) {
+		if ev.err != nil {
+			r.errs.add(ev.err)
+			return false
+		}
+		r.startNext()
+		return true
+	}
+	return r.restart(ev.u, stop)
+}
+
+// restart restarts the unit and its siblings according to the strategy.
+// Returns false if the restarts are exhausted or stop is closed.
+func (r *run) restart(u *unit, stop <-chan struct{}) bool {
+	o := r.opts.supervise
+	now := time.Now()
+	if o.restartWindow > 0 {
+		for len(r.restarts) > 0 && now.Sub(r.restarts[0]) > o.restartWindow {
+			r.restarts = r.restarts[1:]
+		}
+	}
+	if o.maxRestarts > 0 && len(r.restarts) >= o.maxRestarts {
+		err := u.error(StageStart, fmt.Errorf("%w: %d restarts", ErrRestartsExhausted, len(r.restarts)))
+		if u.err != nil {
+			err = u.error(u.err.Stage, fmt.Errorf("%w: %d restarts: %w", ErrRestartsExhausted, len(r.restarts), u.err.Err))
+		}
+		r.errs.add(err)
+		r.obs.notify(Event{Type: EventError, Name: u.name, Err: err})
+		return false
+	}
+
+	// The started units from first are restarted, except for OneForOne.
+	// The siblings are fixed now, as the requests served meanwhile may add and remove units.
+	first := u.index
+	if r.opts.strategy == OneForAll {
+		first = 0
+	}
+	var siblings []*unit
+	if r.opts.strategy != OneForOne {
+		siblings = append(siblings, r.units[first:]...)
+		// Errors of the siblings that are stopped to be restarted are expected, so they're dropped.
+		// If stop is closed meanwhile, the restart is given up and the shutdown takes over,
+		// so that a sibling that doesn't stop is bounded by the shutdown timeout.
+		for i := len(siblings) - 1; i >= 0; i-- {
+			if sibling := siblings[i]; sibling != u && sibling.started && !sibling.removed && !sibling.cleanup {
+				if err := r.stop(sibling, nil, stop); err == errInterrupted {
+					return false
+				}
+			}
+		}
+	}
+
+	t := time.NewTimer(o.backoff.delay(len(r.restarts)))
+	defer t.Stop()
+	for waiting := true; waiting; {
+		select {
+		case <-stop:
+			return false
+		case fn := <-r.requests:
+			fn()
+		case <-t.C:
+			waiting = false
+		}
+	}
+	r.restarts = append(r.restarts, now)
+
+	if r.opts.strategy == OneForOne {
+		if !u.removed {
+			r.start(u)
+		}
+		return true
+	}
+	for _, sibling := range siblings {
+		if sibling == u || !sibling.cleanup {
+			sibling.started, sibling.up = false, false
+		}
+	}
+	r.startNext()
+	return true
+}
+
+// startNext starts the units whose dependencies are all up.
+func (r *run) startNext() {
+next:
+	for _, u := range r.units {
+		if u.started {
+			continue
+		}
+		for _, dep := range u.deps {
+			if !dep.up {
+				continue next
+			}
+		}
+		r.start(u)
+	}
+}
+
+// start starts a new generation of the unit.
+func (r *run) start(u *unit) {
+	if u.gen > 0 {
+		ev := Event{Type: EventRestart, Name: u.name}
+		if u.err != nil {
+			ev.Err = u.err
+		}
+		r.obs.notify(ev)
+	}
+	r.obs.notify(Event{Type: EventStart, Name: u.name})
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	u.gen++
+	u.started = true
+	u.cancel = cancel
+	u.up, u.stopping, u.exited, u.err = false, false, false, nil
+	u.startedAt = time.Now()
+	r.running++
+
+	rd, ok := find[ReadyNotifier](u.rn)
+	u.notifier = ok
+	u.entry.setStatus(func(s *RunnableStatus) {
+		s.State = RunnableRunning
+		if u.notifier {
+			s.State = RunnableStarting
+		}
+		s.StartedAt = u.startedAt
+		if u.gen > 1 {
+			s.Restarts++
+		}
+	})
+
+	gen, startedAt := u.gen, u.startedAt
+	x := &exit{done: make(chan struct{})}
+	u.exit = x
+	go func() {
+		err := u.run(withRestartHook(withName(ctx, u.name), func(err error) {
+			u.restarted(err)
+			r.obs.notify(Event{Type: EventRestart, Name: u.name, Err: err})
+		}))
+		ev := event{u: u, gen: gen, exited: true}
+		if errors.Is(err, ErrStopGroup) {
+			ev.stopGroup, err = true, nil
+		}
+		if err != nil {
+			stage := StageStart
+			if ctx.Err() != nil {
+				stage = StageStop
+			}
+			ev.err = &RunnableError{Name: u.name, Stage: stage, Uptime: time.Since(startedAt), Err: err}
+		}
+		x.err = ev.err
+		close(x.done)
+		r.send(ev)
+	}()
+
+	if ok {
+		ready := rd.Ready()
+		go func() {
+			select {
+			case <-ready:
+				r.send(event{u: u, gen: gen})
+			case <-x.done: // the exit event makes the unit up
+			case <-r.done:
+			}
+		}()
+	}
+}
+
+func (r *run) send(ev event) {
+	select {
+	case r.events <- ev:
+	case <-r.done:
+	}
+}
+
+// shutdown stops the started units one by one in reverse start order.
+func (r *run) shutdown() {
+	var deadline <-chan time.Time
+	if r.opts.shutdownTimeout > 0 {
+		t := time.NewTimer(r.opts.shutdownTimeout)
+		defer t.Stop()
+		deadline = t.C
+	}
+	if r.opts.watchdog.grace > 0 {
+		t := time.NewTimer(r.opts.watchdog.grace)
+		defer t.Stop()
+		r.watchdog = t.C
+	}
+
+	r.flush()
+	for i := len(r.units) - 1; i >= 0; i-- {
+		if !r.units[i].started {
+			continue
+		}
+		err := r.stop(r.units[i], deadline, nil)
+		r.flush()
+		if err == errDeadline {
+			r.errs.add(r.shutdownTimeout())
+			return
+		}
+		if err != nil {
+			r.errs.add(err)
+		}
+	}
+}
+
+// flush records the errors of the pending events.
+func (r *run) flush() {
+	for _, ev := range r.pending {
+		if ev.err != nil && ev.gen == ev.u.gen {
+			r.errs.add(ev.err)
+		}
+	}
+	r.pending = nil
+}
+
+// shutdownTimeout cancels all the units and reports the ones that are still running.
+func (r *run) shutdownTimeout() error {
+	err := &ShutdownTimeoutError{Timeout: r.opts.shutdownTimeout}
+	for _, u := range r.units {
+		if !u.started {
+			continue
+		}
+		u.cancel()
+		if !u.exited {
+			err.Running = append(err.Running, u.name)
+			r.markStopping(u)
+		}
+	}
+	if r.opts.shutdownTimeoutHandler != nil {
+		r.opts.shutdownTimeoutHandler(err)
+	}
+	return err
+}
+
+var (
+	// errDeadline is returned by run.stop when the shutdown deadline is exceeded.
+	errDeadline = errors.New("shutdown deadline exceeded")
+	// errInterrupted is returned by run.stop when the interrupt channel is closed.
+	errInterrupted = errors.New("stop interrupted")
+)
+
+// stop cancels the unit and waits for it to exit within its stop timeout.
+// Returns the error the unit exited with. If the unit doesn't exit in time,
+// it's abandoned and an error wrapping ErrAbandoned is returned.
+// If the deadline fires first, errDeadline is returned, if interrupt is closed first, errInterrupted.
+// Events of other units received meanwhile are left pending.
+func (r *run) stop(u *unit, deadline <-chan time.Time, interrupt <-chan struct{}) error {
+	u.cancel()
+	if u.exited {
+		return nil
+	}
+	r.markStopping(u)
+
+	var timeout <-chan time.Time
+	if u.stopTimeout > 0 {
+		t := time.NewTimer(u.stopTimeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+	requests := r.loopRequests()
+	for !u.exited && !u.removed {
+		select {
+		case fn := <-requests:
+			fn()
+		case ev := <-r.events:
+			if r.receive(ev) && ev.u != u {
+				r.pending = append(r.pending, ev)
+			}
+		case <-timeout:
+			err := u.error(StageStop, fmt.Errorf("%w: not stopped within %s", ErrAbandoned, u.stopTimeout))
+			u.entry.setStatus(func(s *RunnableStatus) {
+				s.State = RunnableFailed
+				s.LastError = err
+			})
+			r.obs.notify(Event{Type: EventError, Name: u.name, Err: err})
+			return err
+		case <-deadline:
+			return errDeadline
+		case <-interrupt:
+			return errInterrupted
+		case <-r.watchdog:
+			r.watchdog = nil
+			r.dumpDiagnostics()
+		}
+	}
+	if u.err != nil {
+		return u.err
+	}
+	return nil
+}
+
+// markStopping reports that the unit is being stopped, once per generation.
+func (r *run) markStopping(u *unit) {
+	if u.stopping {
+		return
+	}
+	u.stopping = true
+	u.entry.setStatus(func(s *RunnableStatus) { s.State = RunnableStopping })
+	r.obs.notify(Event{Type: EventStopping, Name: u.name})
+}
+
+// unit is a Runnable registered in a Group along with its run state.
+type unit struct {
+	entry         *entry
+	rn            Runnable
+	index         int // index in the start order
+	name          string
+	phase         int
+	deps          []*unit
+	waitsExit     []string // names of the dependencies that are up only once they have finished
+	stopTimeout   time.Duration
+	recoverPanics bool
+	cleanup       bool // the unit releases a resource, it isn't stopped to restart its siblings (see FromCleanup)
+
+	gen       int // incremented on each start and on removal
+	started   bool
+	cancel    context.CancelFunc
+	exit      *exit // exit of the current generation
+	startedAt time.Time
+	notifier  bool // the Runnable is a ReadyNotifier
+	up        bool // ready or exited
+	stopping  bool // the unit has been reported as stopping
+	removed   bool // the unit has been removed from the run, see run.remove
+	exited    bool
+	err       *RunnableError // error the unit exited with
+}
+
+// exit is the result of a single generation of a unit.
+type exit struct {
+	done chan struct{}  // closed once the Runnable has returned
+	err  *RunnableError // error the Runnable returned with, set before done is closed
+}
+
+func newUnit(e *entry) *unit {
+	u := &unit{
+		entry: e,
+		rn:    e.rn,
+		name:  e.name,
+		phase: e.phase,
+	}
+	if st, ok := find[*stopTimeoutRunnable](e.rn); ok {
+		u.stopTimeout = st.timeout
+	}
+	if ss, ok := find[*startStopRunnable](e.rn); ok {
+		u.cleanup = ss.cleanup
+	}
+	e.setStatus(func(s *RunnableStatus) { *s = RunnableStatus{Name: e.name} })
+	return u
+}
+
+func (u *unit) run(ctx context.Context) (err error) {
+	if u.recoverPanics {
+		defer func() {
+			if p := recover(); p != nil {
+				pErr := newPanicError(p)
+				pErr.Name = u.name
+				err = pErr
+			}
+		}()
+	}
+	return u.rn.Start(ctx)
+}
+
+// restarted records a restart of the Runnable by Supervise.
+// It's called from the goroutine of the unit.
+func (u *unit) restarted(err error) {
+	u.entry.setStatus(func(s *RunnableStatus) {
+		s.StartedAt = time.Now()
+		s.Restarts++
+		if err != nil {
+			s.LastError = err
+		}
+	})
+}
+
+func (u *unit) error(stage Stage, err error) *RunnableError {
+	return &RunnableError{Name: u.name, Stage: stage, Uptime: time.Since(u.startedAt), Err: err}
+}
@@ -0,0 +1,78 @@
+package runy
+
+import (
+	"context"
+	"time"
+)
+
+// Named returns a Runnable that is registered in a Group under the given name.
+// The name identifies the Runnable in errors (see RunnableError) and other reports of the Group.
+// Unnamed Runnables are identified by their type and registration index, e.g. "*main.Worker#2".
+func Named(name string, rn Runnable) Runnable {
+	return &namedRunnable{rn: rn, name: name}
+}
+
+type namedRunnable struct {
+	rn   Runnable
+	name string
+}
+
+func (r *namedRunnable) Start(ctx context.Context) error {
+	return r.rn.Start(ctx)
+}
+
+func (r *namedRunnable) Unwrap() Runnable {
+	return r.rn
+}
+
+// StopTimeout returns a Runnable that limits the time a Group waits for rn to stop.
+// If rn doesn't return within timeout after its context is canceled, the Group abandons it
+// and reports an error wrapping ErrAbandoned. A non-positive timeout means no limit.
+func StopTimeout(rn Runnable, timeout time.Duration) Runnable {
+	return &stopTimeoutRunnable{rn: rn, timeout: timeout}
+}
+
+type stopTimeoutRunnable struct {
+	rn      Runnable
+	timeout time.Duration
+}
+
+func (r *stopTimeoutRunnable) Start(ctx context.Context) error {
+	return r.rn.Start(ctx)
+}
+
+func (r *stopTimeoutRunnable) Unwrap() Runnable {
+	return r.rn
+}
+
+// find returns the first Runnable in the chain of rn that is a T.
+// The chain consists of rn itself followed by the Runnables obtained by repeatedly calling Unwrap.
+func find[T any](rn Runnable) (T, bool) {
+	for ; rn != nil; rn = unwrapOnce(rn) {
+		if t, ok := rn.(T); ok {
+			return t, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// unwrap returns the innermost Runnable in the chain of rn.
+func unwrap(rn Runnable) Runnable {
+	for {
+		next := unwrapOnce(rn)
+		if next == nil {
+			return rn
+		}
+		rn = next
+	}
+}
+
+// unwrapOnce returns the result of calling the Unwrap method on rn, or nil if rn has no such method.
+func unwrapOnce(rn Runnable) Runnable {
+	u, ok := rn.(interface{ Unwrap() Runnable })
+	if !ok {
+		return nil
+	}
+	return u.Unwrap()
+}
@@ -2,9 +2,9 @@ package runy
 
 import (
 	"context"
+	"fmt"
 	"sync"
-
-	"golang.org/x/sync/errgroup"
+	"time"
 )
 
 var _g = NewGroup()
@@ -34,16 +34,59 @@ func SAddF(start StartFunc, stop StopFunc, opts ...FromSugaredOption) Group {
 	return _g.SAddF(start, stop, opts...)
 }
 
-// Start runs all registered Runnables in the default Group concurrently.
+// WaitReady blocks until all Runnables in the default Group are ready.
+// See Group.WaitReady.
+func WaitReady(ctx context.Context) error {
+	return _g.WaitReady(ctx)
+}
+
+// Spawn registers a Runnable to the default Group and returns a Handle to stop and remove it.
+// See Group.Spawn.
+func Spawn(rn Runnable) (*Handle, error) {
+	return _g.Spawn(rn)
+}
+
+// NextPhase starts a new startup phase in the default Group.
+// Returns the Group for method chaining.
+func NextPhase() Group {
+	return _g.NextPhase()
+}
+
+// Start runs all registered Runnables in the default Group phase by phase.
 // This function blocks until all Runnables complete or the context is canceled.
 func Start(ctx context.Context) (err error) {
 	return _g.Start(ctx)
 }
 
+// State returns the current state of the default Group.
+func State() GroupState {
+	return _g.State()
+}
+
+// Subscribe returns a channel of lifecycle events of the default Group. See Group.Subscribe.
+func Subscribe(buffer int) (<-chan Event, func()) {
+	return _g.Subscribe(buffer)
+}
+
+// Status returns a snapshot of the status of each Runnable in the default Group. See Group.Status.
+func Status() []RunnableStatus {
+	return _g.Status()
+}
+
+// Reset unregisters all Runnables from the default Group. See Group.Reset.
+func Reset() error {
+	return _g.Reset()
+}
+
 // Group manages a collection of Runnables that can be started together.
+// A Group is a Runnable itself, so Groups can be nested, e.g. to give a part of the application
+// its own Strategy. A Group can be started again once its previous Start has returned:
+// the same registered Runnables are run again, e.g. by an integration test suite.
 type Group interface {
 	// Add registers the provided Runnables to the Group.
-	// Returns the Group for method chaining.
+	// If the Group is starting or running, the Runnables are started right away.
+	// If a Runnable can't be started, e.g. because the Group is stopping (ErrGroupStopping),
+	// the error is returned by Start. Returns the Group for method chaining.
 	Add(...Runnable) Group
 
 	// AddF registers the provided RunnableFuncs to the Group.
@@ -59,26 +102,193 @@ type Group interface {
 	// Returns the Group for method chaining.
 	SAddF(StartFunc, StopFunc, ...FromSugaredOption) Group
 
-	// Start runs all registered Runnables concurrently.
+	// Spawn registers a Runnable like Add and returns a Handle to stop and remove it individually.
+	// If the Group is starting or running, the Runnable is started right away,
+	// and an error is returned if it can't be, e.g. ErrMissingDependency or ErrGroupStopping.
+	Spawn(Runnable) (*Handle, error)
+
+	// NextPhase starts a new startup phase.
+	// Runnables registered after NextPhase are started only after every Runnable
+	// of the previous phase is ready (see ReadyNotifier) or has finished.
+	// Returns the Group for method chaining.
+	NextPhase() Group
+
+	// Start runs all registered Runnables phase by phase.
+	// Runnables of the same phase run concurrently, unless they depend on each other (see DependsOn).
+	// Dependency cycles and missing dependencies are reported before anything is started.
+	// Failed Runnables are restarted according to the Strategy of the Group, if any (see WithStrategy).
+	// When the context is canceled or any Runnable fails, the started Runnables are stopped
+	// one by one in reverse start order, each within its stop timeout (see StopTimeout).
+	// The start order is the registration order adjusted so that dependencies go first.
 	// This function blocks until all Runnables complete or the context is canceled.
+	// Returns ErrGroupStarted if the Group is already started and hasn't stopped yet.
+	// All errors that occur during the run and the shutdown are returned as a *MultiError.
+	// Errors of the Runnables are reported as *RunnableError.
+	// If the shutdown takes longer than the shutdown timeout (see WithShutdownTimeout),
+	// a *ShutdownTimeoutError is reported.
 	Start(context.Context) error
+
+	// Ready returns a channel that's closed once all registered Runnables of the current Start
+	// have been started and the ones that are ReadyNotifiers are ready or have finished.
+	// It makes a nested Group a ReadyNotifier.
+	Ready() <-chan struct{}
+
+	// WaitReady blocks until all registered Runnables of the current or the next Start
+	// have been started and the ones that are ReadyNotifiers are ready or have finished.
+	// Returns ErrNotReady if Start returns before that, or the context error if the context is done first.
+	WaitReady(context.Context) error
+
+	// State returns the current state of the Group.
+	State() GroupState
+
+	// Subscribe returns a channel of lifecycle events of the Group with the given buffer size,
+	// and a function that unsubscribes and closes the channel.
+	// Events are never blocked on: if the buffer is full, the event is dropped for the subscriber.
+	// See WithHooks to observe every event synchronously.
+	Subscribe(buffer int) (<-chan Event, func())
+
+	// Status returns a snapshot of the status of each registered Runnable in registration order.
+	// The status reflects the current Start, or the last one if the Group isn't started.
+	// It's safe to call concurrently with Start, e.g. from a health endpoint.
+	Status() []RunnableStatus
+
+	// Reset unregisters all Runnables and returns the Group to StateCreated,
+	// so that it can be set up from scratch. Options of the Group are kept.
+	// Returns ErrGroupStarted if the Group is started and hasn't stopped yet.
+	Reset() error
 }
 
 // NewGroup creates a new empty Group.
-func NewGroup() Group {
-	return &group{}
+func NewGroup(opts ...GroupOption) Group {
+	o := defaultGroupOptions()
+	for _, opt := range opts {
+		opt.applyGroup(&o)
+	}
+	var hooks []Hooks
+	if o.logger != nil {
+		hooks = append(hooks, &logHooks{l: o.logger})
+	}
+	if o.metrics != nil {
+		hooks = append(hooks, &metricsHooks{m: o.metrics})
+	}
+	hooks = append(hooks, o.hooks...)
+	return &group{
+		opts:    o,
+		obs:     &observer{hooks: hooks},
+		ready:   make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+type groupOptions struct {
+	shutdownTimeout        time.Duration
+	shutdownTimeoutHandler func(err *ShutdownTimeoutError)
+	recoverPanics          bool
+	strategy               Strategy
+	supervise              superviseOptions
+	hooks                  []Hooks
+	logger                 Logger
+	metrics                Metrics
+	watchdog               watchdogOptions
 }
 
+func defaultGroupOptions() groupOptions {
+	return groupOptions{recoverPanics: true}
+}
+
+// GroupOption modifies the behavior of a Group.
+type GroupOption interface {
+	applyGroup(o *groupOptions)
+}
+
+// groupOptionFunc is a GroupOption implemented by a function.
+type groupOptionFunc func(o *groupOptions)
+
+func (f groupOptionFunc) applyGroup(o *groupOptions) {
+	f(o)
+}
+
+// WithShutdownTimeout limits the overall time a Group waits for its Runnables to stop.
+// When the timeout is exceeded, Group.Start stops waiting and returns a *ShutdownTimeoutError
+// naming every Runnable that is still running. A non-positive timeout means no limit.
+func WithShutdownTimeout(timeout time.Duration) GroupOption {
+	return groupOptionFunc(func(o *groupOptions) {
+		o.shutdownTimeout = timeout
+	})
+}
+
+// WithShutdownTimeoutHandler sets a function that is called with the *ShutdownTimeoutError
+// before Group.Start returns it. It can be used as a hard-exit fallback, e.g.:
+//
+//	runy.WithShutdownTimeoutHandler(func(err *runy.ShutdownTimeoutError) {
+//		_ = pprof.Lookup("goroutine").WriteTo(os.Stderr, 2)
+//		log.Fatal(err)
+//	})
+func WithShutdownTimeoutHandler(fn func(err *ShutdownTimeoutError)) GroupOption {
+	return groupOptionFunc(func(o *groupOptions) {
+		o.shutdownTimeoutHandler = fn
+	})
+}
+
+// WithPanicRecovery enables or disables recovery of panics in Runnables. It's enabled by default.
+// A recovered panic is reported as a *PanicError and the Group is shut down in order,
+// as if the Runnable had returned an error.
+func WithPanicRecovery(enabled bool) GroupOption {
+	return groupOptionFunc(func(o *groupOptions) {
+		o.recoverPanics = enabled
+	})
+}
+
+var _ Runnable = (*group)(nil)
+
 type group struct {
-	mu        sync.Mutex
-	once      sync.Once
-	runnables []Runnable
+	opts    groupOptions
+	obs     *observer
+	mu      sync.Mutex
+	run     *run       // current run, nil if the Group isn't started
+	state   GroupState // state of the Group when it isn't started
+	entries []*entry
+	seq     int // number of Runnables ever registered
+	phase   int // phase assigned to newly registered Runnables
+
+	// Channels of the current or the next Start, replaced when Start returns.
+	ready   chan struct{} // closed once all the Runnables are ready
+	stopped chan struct{} // closed once Start returns
+}
+
+// entry is a Runnable registered in a Group.
+type entry struct {
+	rn    Runnable
+	name  string
+	phase int
+
+	mu     sync.Mutex
+	status RunnableStatus // status of the Runnable in the current or the last Start
+}
+
+// setStatus updates the status of the Runnable with fn.
+func (e *entry) setStatus(fn func(s *RunnableStatus)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	fn(&e.status)
+}
+
+func (e *entry) getStatus() RunnableStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.status
 }
 
 func (g *group) Add(rns ...Runnable) Group {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-	g.runnables = append(g.runnables, rns...)
+	for _, rn := range rns {
+		if _, err := g.add(rn); err != nil {
+			g.mu.Lock()
+			if g.run != nil {
+				g.run.errs.add(err)
+			}
+			g.mu.Unlock()
+		}
+	}
 	return g
 }
 
@@ -97,16 +307,192 @@ func (g *group) SAddF(start StartFunc, stop StopFunc, opts ...FromSugaredOption)
 	return g.Add(FromSugared(SugaredFromFuncs(start, stop), opts...))
 }
 
-func (g *group) Start(ctx context.Context) (err error) {
-	g.once.Do(func() {
-		eg, ctx := errgroup.WithContext(ctx)
-		for _, rn := range g.runnables {
-			rn := rn
-			eg.Go(func() error {
-				return rn.Start(ctx)
-			})
+func (g *group) Spawn(rn Runnable) (*Handle, error) {
+	e, err := g.add(rn)
+	if err != nil {
+		return nil, err
+	}
+	return &Handle{g: g, e: e}, nil
+}
+
+// add registers rn and starts it right away if the Group is running.
+// If rn can't be started, it's unregistered.
+func (g *group) add(rn Runnable) (*entry, error) {
+	g.mu.Lock()
+	e := &entry{rn: rn, name: fmt.Sprintf("%T#%d", unwrap(rn), g.seq), phase: g.phase}
+	if n, ok := find[*namedRunnable](rn); ok {
+		e.name = n.name
+	}
+	e.status.Name = e.name
+	g.seq++
+	g.entries = append(g.entries, e)
+	r := g.run
+	g.mu.Unlock()
+	if g.opts.logger != nil {
+		g.opts.logger.Debug("runnable registered", "name", e.name, "phase", e.phase)
+	}
+
+	if r == nil {
+		return e, nil
+	}
+	if err := r.add(e); err != nil {
+		g.remove(e)
+		return nil, err
+	}
+	return e, nil
+}
+
+// remove unregisters the entry. Returns false if it isn't registered.
+func (g *group) remove(e *entry) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, other := range g.entries {
+		if other == e {
+			g.entries = append(g.entries[:i:i], g.entries[i+1:]...)
+			return true
 		}
-		err = eg.Wait()
-	})
-	return err
+	}
+	return false
+}
+
+func (g *group) NextPhase() Group {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.entries) > 0 && g.entries[len(g.entries)-1].phase == g.phase {
+		g.phase++
+	}
+	return g
+}
+
+func (g *group) Start(ctx context.Context) error {
+	g.mu.Lock()
+	if g.run != nil {
+		g.mu.Unlock()
+		return ErrGroupStarted
+	}
+	units := make([]*unit, len(g.entries))
+	for i, e := range g.entries {
+		units[i] = newUnit(e)
+	}
+	units, err := resolve(units)
+	if err != nil {
+		g.mu.Unlock()
+		return err
+	}
+	r := newRun(g.opts, units, g.ready, g.obs)
+	g.run = r
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.run = nil
+		g.state = StateStopped
+		close(g.stopped)
+		g.ready, g.stopped = make(chan struct{}), make(chan struct{})
+	}()
+	return r.execute(ctx)
+}
+
+func (g *group) Ready() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.ready
+}
+
+func (g *group) WaitReady(ctx context.Context) error {
+	g.mu.Lock()
+	ready, stopped := g.ready, g.stopped
+	g.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-stopped:
+		select {
+		case <-ready:
+			return nil
+		default:
+			return ErrNotReady
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (g *group) State() GroupState {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.run == nil {
+		return g.state
+	}
+	select {
+	case <-g.run.stopping:
+		return StateStopping
+	default:
+	}
+	select {
+	case <-g.ready:
+		return StateRunning
+	default:
+		return StateStarting
+	}
+}
+
+func (g *group) Subscribe(buffer int) (<-chan Event, func()) {
+	return g.obs.subscribe(buffer)
+}
+
+func (g *group) Status() []RunnableStatus {
+	g.mu.Lock()
+	entries := append([]*entry(nil), g.entries...)
+	g.mu.Unlock()
+
+	statuses := make([]RunnableStatus, len(entries))
+	for i, e := range entries {
+		statuses[i] = e.getStatus()
+	}
+	return statuses
+}
+
+func (g *group) Reset() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.run != nil {
+		return ErrGroupStarted
+	}
+	g.entries, g.seq, g.phase = nil, 0, 0
+	g.state = StateCreated
+	return nil
+}
+
+// Handle controls a Runnable registered with Group.Spawn.
+type Handle struct {
+	g *group
+	e *entry
+}
+
+// Name returns the name of the Runnable (see Named).
+func (h *Handle) Name() string {
+	return h.e.name
+}
+
+// Stop removes the Runnable from the Group. If the Group is running, the Runnable is stopped
+// without affecting the others, and Stop blocks until it returns or the context is done.
+// Returns the error the Runnable returned with, if any. Subsequent calls do nothing.
+// The Group doesn't wait for the Runnable, so a Runnable that doesn't stop doesn't block it,
+// but it's abandoned only after its stop timeout (see StopTimeout).
+// A Runnable must not stop itself through its Handle: Stop would wait for it to return
+// until ctx is done. Return from Start instead.
+func (h *Handle) Stop(ctx context.Context) error {
+	if !h.g.remove(h.e) {
+		return nil
+	}
+	h.g.mu.Lock()
+	r := h.g.run
+	h.g.mu.Unlock()
+	if r == nil {
+		return nil
+	}
+	return r.remove(ctx, h.e)
 }
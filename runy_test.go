@@ -2,7 +2,9 @@ package runy
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -11,7 +13,7 @@ import (
 
 func setupTest(t *testing.T) *group {
 	t.Helper()
-	t.Cleanup(func() { _g = NewGroup() })
+	t.Cleanup(func() { assert.NoError(t, Reset()) })
 	return _g.(*group)
 }
 
@@ -25,18 +27,18 @@ func TestRuny(t *testing.T) {
 		// Test individual registration methods
 		Add(run)
 		AddF(runF)
-		assert.Equal(t, 2, len(g.runnables))
+		assert.Equal(t, 2, len(g.entries))
 
 		// Test method chaining with multiple arguments
 		Add(run).Add(run, run)
-		assert.Equal(t, 5, len(g.runnables))
+		assert.Equal(t, 5, len(g.entries))
 
 		AddF(runF).AddF(runF, runF)
-		assert.Equal(t, 8, len(g.runnables))
+		assert.Equal(t, 8, len(g.entries))
 
 		// Test mixed chaining
 		AddF(runF).Add(run).AddF(runF).Add(run)
-		assert.Equal(t, 12, len(g.runnables))
+		assert.Equal(t, 12, len(g.entries))
 	})
 
 	t.Run("registration sugared", func(t *testing.T) {
@@ -49,18 +51,18 @@ func TestRuny(t *testing.T) {
 		// Test individual registration methods
 		SAdd(runS)
 		SAddF(runStart, runStop)
-		assert.Equal(t, 2, len(g.runnables))
+		assert.Equal(t, 2, len(g.entries))
 
 		// Test method chaining
 		SAdd(runS).SAdd(runS)
-		assert.Equal(t, 4, len(g.runnables))
+		assert.Equal(t, 4, len(g.entries))
 
 		SAddF(runStart, runStop).SAddF(runStart, runStop)
-		assert.Equal(t, 6, len(g.runnables))
+		assert.Equal(t, 6, len(g.entries))
 
 		// Test mixed chaining
 		SAddF(runStart, runStop).SAdd(runS).SAddF(runStart, runStop).SAdd(runS)
-		assert.Equal(t, 10, len(g.runnables))
+		assert.Equal(t, 10, len(g.entries))
 	})
 
 	t.Run("concurrent execution", func(t *testing.T) {
@@ -159,3 +161,693 @@ func TestRuny(t *testing.T) {
 		}
 	})
 }
+
+func TestRunyPhases(t *testing.T) {
+	t.Run("next phase waits for finish", func(t *testing.T) {
+		setupTest(t)
+
+		var migrated bool
+		AddF(func(ctx context.Context) error {
+			time.Sleep(50 * time.Millisecond)
+			migrated = true
+			return nil
+		})
+		NextPhase().AddF(func(ctx context.Context) error {
+			assert.True(t, migrated, "second phase started before the first one finished")
+			return nil
+		})
+
+		assert.NoError(t, Start(context.Background()))
+	})
+
+	t.Run("next phase waits for ready", func(t *testing.T) {
+		setupTest(t)
+
+		cache := newReadyRunnable()
+		Add(cache)
+		NextPhase().AddF(func(ctx context.Context) error {
+			select {
+			case <-cache.Ready():
+			default:
+				assert.Fail(t, "second phase started before the first one was ready")
+			}
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+
+		time.Sleep(20 * time.Millisecond)
+		close(cache.ready)
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+
+		select {
+		case err := <-errCh:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			assert.Fail(t, "runy.Start() didn't return in time")
+		}
+	})
+
+	t.Run("reverse shutdown order", func(t *testing.T) {
+		setupTest(t)
+
+		var mu sync.Mutex
+		var stopped []int
+		blocking := func(i int) RunnableFunc {
+			return func(ctx context.Context) error {
+				<-ctx.Done()
+				mu.Lock()
+				defer mu.Unlock()
+				stopped = append(stopped, i)
+				return nil
+			}
+		}
+		first, second := newReadyRunnableFunc(blocking(1)), newReadyRunnableFunc(blocking(2))
+		close(first.ready)
+		close(second.ready)
+		Add(first).NextPhase().Add(second).NextPhase().AddF(blocking(3))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.NoError(t, Start(ctx))
+		assert.Equal(t, []int{3, 2, 1}, stopped)
+	})
+
+	t.Run("failed phase prevents next", func(t *testing.T) {
+		setupTest(t)
+
+		AddF(func(ctx context.Context) error { return assert.AnError })
+		NextPhase().AddF(func(ctx context.Context) error {
+			assert.Fail(t, "second phase started after the first one failed")
+			return nil
+		})
+
+		assert.ErrorIs(t, Start(context.Background()), assert.AnError)
+	})
+
+	t.Run("empty phases are skipped", func(t *testing.T) {
+		g := setupTest(t)
+
+		NextPhase().NextPhase()
+		AddF(func(ctx context.Context) error { return nil })
+		NextPhase().NextPhase()
+		AddF(func(ctx context.Context) error { return nil })
+		assert.Equal(t, 0, g.entries[0].phase)
+		assert.Equal(t, 1, g.entries[1].phase)
+		assert.NoError(t, Start(context.Background()))
+	})
+}
+
+// readyRunnable is a Runnable that blocks until the context is canceled
+// and reports readiness once the ready channel is closed.
+type readyRunnable struct {
+	run   RunnableFunc
+	ready chan struct{}
+}
+
+func newReadyRunnable() *readyRunnable {
+	return newReadyRunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+}
+
+func newReadyRunnableFunc(run RunnableFunc) *readyRunnable {
+	return &readyRunnable{run: run, ready: make(chan struct{})}
+}
+
+func (r *readyRunnable) Start(ctx context.Context) error {
+	return r.run(ctx)
+}
+
+func (r *readyRunnable) Ready() <-chan struct{} {
+	return r.ready
+}
+
+func TestRunyShutdown(t *testing.T) {
+	t.Run("reverse registration order", func(t *testing.T) {
+		setupTest(t)
+
+		var mu sync.Mutex
+		var stopped []int
+		for i := 0; i < 5; i++ {
+			i := i
+			AddF(func(ctx context.Context) error {
+				<-ctx.Done()
+				mu.Lock()
+				defer mu.Unlock()
+				stopped = append(stopped, i)
+				return nil
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.NoError(t, Start(ctx))
+		assert.Equal(t, []int{4, 3, 2, 1, 0}, stopped)
+	})
+
+	t.Run("next stops after previous returned", func(t *testing.T) {
+		setupTest(t)
+
+		var producerClosed, serverDrained bool
+		AddF(func(ctx context.Context) error { // producer
+			<-ctx.Done()
+			assert.True(t, serverDrained, "producer stopped before server drained")
+			producerClosed = true
+			return nil
+		})
+		AddF(func(ctx context.Context) error { // server
+			<-ctx.Done()
+			time.Sleep(50 * time.Millisecond)
+			serverDrained = true
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.NoError(t, Start(ctx))
+		assert.True(t, producerClosed)
+	})
+
+	t.Run("stop timeout", func(t *testing.T) {
+		setupTest(t)
+
+		release := make(chan struct{})
+		defer close(release)
+		var stopped bool
+		AddF(func(ctx context.Context) error {
+			<-ctx.Done()
+			stopped = true
+			return nil
+		})
+		Add(StopTimeout(RunnableFunc(func(ctx context.Context) error {
+			<-release // ignores ctx.Done()
+			return nil
+		}), 50*time.Millisecond))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := Start(ctx)
+		assert.ErrorIs(t, err, ErrAbandoned)
+		assert.ErrorContains(t, err, "runy.RunnableFunc#1")
+		assert.True(t, stopped, "remaining runnables weren't stopped after abandoning")
+	})
+
+	t.Run("stop in time", func(t *testing.T) {
+		setupTest(t)
+
+		Add(StopTimeout(RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}), time.Second))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.NoError(t, Start(ctx))
+	})
+	t.Run("shutdown timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		stuck := func(ctx context.Context) error {
+			<-release // ignores ctx.Done()
+			return nil
+		}
+
+		var handled *ShutdownTimeoutError
+		g := NewGroup(
+			WithShutdownTimeout(50*time.Millisecond),
+			WithShutdownTimeoutHandler(func(err *ShutdownTimeoutError) { handled = err }),
+		)
+		g.AddF(stuck, stuck, func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := g.Start(ctx)
+
+		var timeoutErr *ShutdownTimeoutError
+		if assert.ErrorAs(t, err, &timeoutErr) {
+			assert.Equal(t, 50*time.Millisecond, timeoutErr.Timeout)
+			assert.Equal(t, []string{"runy.RunnableFunc#0", "runy.RunnableFunc#1"}, timeoutErr.Running)
+		}
+		assert.Same(t, timeoutErr, handled)
+	})
+
+	t.Run("shutdown in time", func(t *testing.T) {
+		var handled bool
+		g := NewGroup(
+			WithShutdownTimeout(time.Second),
+			WithShutdownTimeoutHandler(func(err *ShutdownTimeoutError) { handled = true }),
+		)
+		g.AddF(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, g.Start(ctx))
+		assert.False(t, handled)
+	})
+}
+
+func TestRunyErrors(t *testing.T) {
+	t.Run("start stage", func(t *testing.T) {
+		setupTest(t)
+
+		Add(Named("db", RunnableFunc(func(ctx context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			return assert.AnError
+		})))
+
+		err := Start(context.Background())
+		var rnErr *RunnableError
+		if assert.ErrorAs(t, err, &rnErr) {
+			assert.Equal(t, "db", rnErr.Name)
+			assert.Equal(t, StageStart, rnErr.Stage)
+			assert.GreaterOrEqual(t, rnErr.Uptime, 20*time.Millisecond)
+		}
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("stop stage", func(t *testing.T) {
+		setupTest(t)
+
+		Add(Named("http", RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return assert.AnError
+		})))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := Start(ctx)
+		var rnErr *RunnableError
+		if assert.ErrorAs(t, err, &rnErr) {
+			assert.Equal(t, "http", rnErr.Name)
+			assert.Equal(t, StageStop, rnErr.Stage)
+		}
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("abandoned", func(t *testing.T) {
+		setupTest(t)
+
+		release := make(chan struct{})
+		defer close(release)
+		Add(Named("kafka", StopTimeout(RunnableFunc(func(ctx context.Context) error {
+			<-release
+			return nil
+		}), 10*time.Millisecond)))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := Start(ctx)
+		var rnErr *RunnableError
+		if assert.ErrorAs(t, err, &rnErr) {
+			assert.Equal(t, "kafka", rnErr.Name)
+			assert.Equal(t, StageStop, rnErr.Stage)
+		}
+		assert.ErrorIs(t, err, ErrAbandoned)
+	})
+
+	t.Run("all errors", func(t *testing.T) {
+		setupTest(t)
+
+		errConsumer, errServer := errors.New("consumer close"), errors.New("server shutdown")
+		Add(Named("consumer", RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return errConsumer
+		})))
+		Add(Named("server", RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return errServer
+		})))
+		AddF(func(ctx context.Context) error { return assert.AnError })
+
+		err := Start(context.Background())
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorIs(t, err, errConsumer)
+		assert.ErrorIs(t, err, errServer)
+
+		var multiErr *MultiError
+		if assert.ErrorAs(t, err, &multiErr) {
+			assert.Len(t, multiErr.Errors, 3)
+			byName := multiErr.RunnableErrors()
+			assert.Len(t, byName, 3)
+			if assert.Len(t, byName["server"], 1) {
+				assert.Equal(t, StageStop, byName["server"][0].Stage)
+				assert.Equal(t, errServer, byName["server"][0].Err)
+			}
+			if assert.Len(t, byName["runy.RunnableFunc#2"], 1) {
+				assert.Equal(t, StageStart, byName["runy.RunnableFunc#2"][0].Stage)
+			}
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		tests := []struct {
+			name string
+			rn   Runnable
+		}{
+			{
+				name: "runnable start",
+				rn:   RunnableFunc(func(ctx context.Context) error { panic("boom") }),
+			},
+			{
+				name: "sugared start",
+				rn: FromSugared(SugaredFromFuncs(func(ctx context.Context) error {
+					panic("boom")
+				}, nil)),
+			},
+			{
+				name: "sugared stop",
+				rn: FromSugared(SugaredFromFuncs(func(ctx context.Context) error {
+					<-ctx.Done()
+					return nil
+				}, func(ctx context.Context) error {
+					panic("boom")
+				})),
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				setupTest(t)
+
+				var stopped bool
+				AddF(func(ctx context.Context) error {
+					<-ctx.Done()
+					stopped = true
+					return nil
+				})
+				Add(Named("panicky", tt.rn))
+
+				ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+				defer cancel()
+				err := Start(ctx)
+
+				var pErr *PanicError
+				if assert.ErrorAs(t, err, &pErr) {
+					assert.Equal(t, "panicky", pErr.Name)
+					assert.Equal(t, "boom", pErr.Value)
+					assert.Contains(t, string(pErr.Stack), "runy_test.go")
+				}
+				var rnErr *RunnableError
+				if assert.ErrorAs(t, err, &rnErr) {
+					assert.Equal(t, "panicky", rnErr.Name)
+				}
+				assert.True(t, stopped, "other runnables weren't stopped after panic")
+			})
+		}
+	})
+
+	t.Run("default names", func(t *testing.T) {
+		g := setupTest(t)
+
+		AddF(func(ctx context.Context) error { return nil })
+		Add(Named("named", RunnableFunc(func(ctx context.Context) error { return nil })))
+		Add(StopTimeout(&readyRunnable{}, time.Second))
+		assert.Equal(t, "runy.RunnableFunc#0", g.entries[0].name)
+		assert.Equal(t, "named", g.entries[1].name)
+		assert.Equal(t, "*runy.readyRunnable#2", g.entries[2].name)
+	})
+}
+
+func TestRunyWaitReady(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		setupTest(t)
+
+		cache := newReadyRunnable()
+		Add(cache)
+		AddF(func(ctx context.Context) error { return nil }) // finished counts as ready
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+
+		waitCtx, waitCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer waitCancel()
+		assert.ErrorIs(t, WaitReady(waitCtx), context.DeadlineExceeded)
+
+		close(cache.ready)
+		assert.NoError(t, WaitReady(context.Background()))
+
+		cancel()
+		assert.NoError(t, <-errCh)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		setupTest(t)
+
+		Add(newReadyRunnable())
+		AddF(func(ctx context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			return assert.AnError
+		})
+
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(context.Background()) }()
+
+		assert.ErrorIs(t, WaitReady(context.Background()), ErrNotReady)
+		assert.Error(t, <-errCh)
+	})
+
+	t.Run("nested group", func(t *testing.T) {
+		setupTest(t)
+
+		inner := NewGroup()
+		innerReady := newReadyRunnable()
+		inner.Add(innerReady)
+
+		started := make(chan struct{})
+		Add(inner)
+		NextPhase().AddF(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+
+		select {
+		case <-started:
+			assert.Fail(t, "second phase started before the nested group was ready")
+		case <-time.After(20 * time.Millisecond):
+		}
+		close(innerReady.ready)
+		assert.NoError(t, WaitReady(context.Background()))
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			assert.Fail(t, "second phase didn't start after the nested group was ready")
+		}
+
+		cancel()
+		assert.NoError(t, <-errCh)
+	})
+
+	t.Run("sugared", func(t *testing.T) {
+		rn := FromSugared(&readySugared{ready: make(chan struct{})})
+		rd, ok := rn.(ReadyNotifier)
+		if assert.True(t, ok) {
+			assert.NotNil(t, rd.Ready())
+		}
+		_, ok = FromSugared(SugaredFromFuncs(nil, nil)).(ReadyNotifier)
+		assert.False(t, ok)
+	})
+}
+
+type readySugared struct {
+	ready chan struct{}
+}
+
+func (r *readySugared) Start(ctx context.Context) error { return nil }
+
+func (r *readySugared) Stop(ctx context.Context) error { return nil }
+
+func (r *readySugared) Ready() <-chan struct{} { return r.ready }
+
+func TestRunyDynamic(t *testing.T) {
+	t.Run("add while running", func(t *testing.T) {
+		setupTest(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		rn := newReadyRunnable()
+		close(rn.ready)
+		Add(rn)
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+		assert.NoError(t, WaitReady(ctx))
+
+		started := make(chan struct{})
+		AddF(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return nil
+		})
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			assert.Fail(t, "runnable added while running didn't start")
+		}
+
+		cancel()
+		assert.NoError(t, <-errCh)
+	})
+
+	t.Run("stop handle", func(t *testing.T) {
+		g := setupTest(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var otherStopped atomic.Bool
+		AddF(func(ctx context.Context) error {
+			<-ctx.Done()
+			otherStopped.Store(true)
+			return nil
+		})
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+
+		started := make(chan struct{})
+		h, err := Spawn(Named("worker", RunnableFunc(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return assert.AnError
+		})))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "worker", h.Name())
+		<-started
+
+		err = h.Stop(context.Background())
+		var rErr *RunnableError
+		if assert.ErrorAs(t, err, &rErr) {
+			assert.Equal(t, "worker", rErr.Name)
+			assert.Equal(t, StageStop, rErr.Stage)
+		}
+		assert.False(t, otherStopped.Load())
+		assert.Len(t, g.entries, 1)
+		assert.NoError(t, h.Stop(context.Background()))
+
+		cancel()
+		assert.NoError(t, <-errCh)
+		assert.True(t, otherStopped.Load())
+	})
+
+	t.Run("stop handle abandoned", func(t *testing.T) {
+		setupTest(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		AddF(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+		assert.NoError(t, WaitReady(ctx))
+
+		started := make(chan struct{})
+		h, err := Spawn(Named("slow", StopTimeout(RunnableFunc(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			time.Sleep(50 * time.Millisecond)
+			return ctx.Err()
+		}), 10*time.Millisecond)))
+		if !assert.NoError(t, err) {
+			return
+		}
+		<-started
+
+		assert.ErrorIs(t, h.Stop(context.Background()), ErrAbandoned)
+		select {
+		case err := <-errCh:
+			assert.Fail(t, "group stopped by the late exit of the removed runnable", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		cancel()
+		assert.NoError(t, <-errCh)
+	})
+
+	t.Run("stop handle stuck", func(t *testing.T) {
+		g := NewGroup(WithShutdownTimeout(100 * time.Millisecond))
+		g.Add(RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		errCh := make(chan error, 1)
+		go func() { errCh <- g.Start(ctx) }()
+		assert.NoError(t, g.WaitReady(ctx))
+
+		release := make(chan struct{})
+		defer close(release)
+		started := make(chan struct{})
+		h, err := g.Spawn(RunnableFunc(func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		}))
+		if !assert.NoError(t, err) {
+			return
+		}
+		<-started
+
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer stopCancel()
+		assert.ErrorIs(t, h.Stop(stopCtx), context.DeadlineExceeded)
+
+		cancel()
+		select {
+		case err := <-errCh:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			assert.Fail(t, "group blocked by the stuck removed runnable")
+		}
+	})
+
+	t.Run("stop handle before start", func(t *testing.T) {
+		g := setupTest(t)
+
+		h, err := Spawn(RunnableFunc(func(ctx context.Context) error { return assert.AnError }))
+		assert.NoError(t, err)
+		assert.NoError(t, h.Stop(context.Background()))
+		assert.Empty(t, g.entries)
+		assert.NoError(t, Start(context.Background()))
+	})
+
+	t.Run("missing dependency", func(t *testing.T) {
+		g := setupTest(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		rn := newReadyRunnable()
+		close(rn.ready)
+		Add(rn)
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+		assert.NoError(t, WaitReady(ctx))
+
+		_, err := Spawn(DependsOn(RunnableFunc(func(ctx context.Context) error { return nil }), "db"))
+		assert.ErrorIs(t, err, ErrMissingDependency)
+		assert.Len(t, g.entries, 1)
+
+		cancel()
+		assert.NoError(t, <-errCh)
+	})
+}
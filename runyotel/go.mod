@@ -0,0 +1,26 @@
+module github.com/belo4ya/runy/runyotel
+
+go 1.20
+
+// The module needs the Hooks and StoppingHooks APIs of runy, which aren't in a tagged version yet.
+// Replace it with the parent directory until they are, then require that version instead.
+replace github.com/belo4ya/runy => ../
+
+require (
+	github.com/belo4ya/runy v0.0.0-20250309132122-a68a02b58ec0
+	github.com/stretchr/testify v1.10.0
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/sdk v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
+	go.opentelemetry.io/otel/metric v1.24.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,231 @@
+// Package runyotel traces the lifecycle of a runy.Group with OpenTelemetry.
+//
+//	t := runyotel.New()
+//	g := runy.NewGroup(runy.WithHooks(t))
+//	err := t.Start(ctx, g)
+//
+// Each Start of the Group is traced as a root span with child spans for the start, ready and stop
+// phases of each Runnable. Errors and panics of the Runnables are recorded as span events.
+package runyotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/belo4ya/runy"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/belo4ya/runy/runyotel"
+
+// Span names.
+const (
+	SpanGroupStart    = "runy.Group.Start"
+	SpanRunnableStart = "runy.runnable.start"
+	SpanRunnableReady = "runy.runnable.ready"
+	SpanRunnableStop  = "runy.runnable.stop"
+)
+
+// Attribute keys.
+const (
+	AttrRunnableName = attribute.Key("runy.runnable.name")
+	AttrPanicValue   = attribute.Key("runy.panic.value")
+	AttrPanicStack   = attribute.Key("runy.panic.stack")
+)
+
+var (
+	_ runy.Hooks         = (*Tracer)(nil)
+	_ runy.StoppingHooks = (*Tracer)(nil)
+)
+
+// Tracer implements runy.Hooks by tracing the lifecycle of a Group.
+// A Tracer must be used with a single Group.
+//
+// The spans of a Runnable are children of the root span of the Group:
+//   - runy.runnable.start: from the start of the Runnable until it's ready. For Runnables that
+//     aren't ReadyNotifiers, it lasts until the Runnable is stopped;
+//   - runy.runnable.ready: from the readiness of the Runnable until it's stopped;
+//   - runy.runnable.stop: from the cancellation of the context of the Runnable until it returns.
+//
+// The spans are keyed by the names of the Runnables, so the names should be unique (see runy.Named).
+type Tracer struct {
+	tracer trace.Tracer
+
+	mu       sync.Mutex
+	ctx      context.Context // context of the root span
+	root     trace.Span
+	external bool // the root span is managed by Start
+	spans    map[string]trace.Span
+}
+
+// New creates a Tracer with the provided options.
+func New(opts ...Option) *Tracer {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Tracer{
+		tracer: o.provider.Tracer(instrumentationName),
+		spans:  make(map[string]trace.Span),
+	}
+}
+
+type options struct {
+	provider trace.TracerProvider
+}
+
+func defaultOptions() options {
+	return options{provider: otel.GetTracerProvider()}
+}
+
+// Option is a function that modifies the behavior of a Tracer.
+type Option func(o *options)
+
+// WithTracerProvider sets the TracerProvider, the global one by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) {
+		o.provider = provider
+	}
+}
+
+// Start runs g.Start within the root span, which is a child of the span in ctx, if any.
+// If g.Start is called directly, the root span is started by the first lifecycle event
+// as a new trace and ended when the shutdown ends.
+func (t *Tracer) Start(ctx context.Context, g runy.Group) error {
+	ctx, span := t.tracer.Start(ctx, SpanGroupStart)
+	defer span.End()
+
+	t.mu.Lock()
+	t.ctx, t.root, t.external = ctx, span, true
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.ctx, t.root, t.external = nil, nil, false
+		t.mu.Unlock()
+	}()
+
+	err := g.Start(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// OnStart implements runy.Hooks.
+func (t *Tracer) OnStart(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.end(name)
+	t.spans[name] = t.startSpan(SpanRunnableStart, name)
+}
+
+// OnReady implements runy.Hooks.
+func (t *Tracer) OnReady(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.end(name)
+	t.spans[name] = t.startSpan(SpanRunnableReady, name)
+}
+
+// OnStopping implements runy.StoppingHooks.
+func (t *Tracer) OnStopping(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.end(name)
+	t.spans[name] = t.startSpan(SpanRunnableStop, name)
+}
+
+// OnStop implements runy.Hooks.
+func (t *Tracer) OnStop(name string, _ error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.end(name)
+}
+
+// OnError implements runy.Hooks.
+func (t *Tracer) OnError(name string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span, ok := t.spans[name]
+	if !ok {
+		span = t.rootSpan()
+	}
+	recordError(span, err, AttrRunnableName.String(name))
+}
+
+// OnRestart implements runy.Hooks.
+func (t *Tracer) OnRestart(name string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	attrs := []attribute.KeyValue{AttrRunnableName.String(name)}
+	if err != nil {
+		attrs = append(attrs, attribute.String("error", err.Error()))
+	}
+	t.rootSpan().AddEvent("restart", trace.WithAttributes(attrs...))
+}
+
+// OnShutdownBegin implements runy.Hooks.
+func (t *Tracer) OnShutdownBegin() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rootSpan().AddEvent("shutdown begin")
+}
+
+// OnShutdownEnd implements runy.Hooks.
+func (t *Tracer) OnShutdownEnd(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for name := range t.spans {
+		t.end(name)
+	}
+	root := t.rootSpan()
+	root.AddEvent("shutdown end")
+	if t.external {
+		return
+	}
+	if err != nil {
+		root.SetStatus(codes.Error, err.Error())
+	}
+	root.End()
+	t.ctx, t.root = nil, nil
+}
+
+// rootSpan returns the root span, starting it if needed.
+func (t *Tracer) rootSpan() trace.Span {
+	if t.root == nil {
+		t.ctx, t.root = t.tracer.Start(context.Background(), SpanGroupStart)
+	}
+	return t.root
+}
+
+func (t *Tracer) startSpan(spanName, name string) trace.Span {
+	t.rootSpan()
+	_, span := t.tracer.Start(t.ctx, spanName, trace.WithAttributes(AttrRunnableName.String(name)))
+	return span
+}
+
+// end ends the current span of the Runnable, if any.
+func (t *Tracer) end(name string) {
+	if span, ok := t.spans[name]; ok {
+		span.End()
+		delete(t.spans, name)
+	}
+}
+
+// recordError records the error as a span event, and the panic if the error is a *runy.PanicError.
+func recordError(span trace.Span, err error, attrs ...attribute.KeyValue) {
+	var pErr *runy.PanicError
+	if errors.As(err, &pErr) {
+		span.AddEvent("panic", trace.WithAttributes(append(attrs,
+			AttrPanicValue.String(fmt.Sprint(pErr.Value)),
+			AttrPanicStack.String(string(pErr.Stack)),
+		)...))
+	}
+	span.RecordError(err, trace.WithAttributes(attrs...))
+	span.SetStatus(codes.Error, err.Error())
+}
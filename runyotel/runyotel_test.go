@@ -0,0 +1,98 @@
+package runyotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/belo4ya/runy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupTest(t *testing.T) (*Tracer, *tracetest.InMemoryExporter) {
+	t.Helper()
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	return New(WithTracerProvider(tp)), exp
+}
+
+// names returns "<span name> <runnable name>" of the ended spans in end order.
+func names(spans tracetest.SpanStubs) []string {
+	var names []string
+	for _, s := range spans {
+		name := s.Name
+		for _, attr := range s.Attributes {
+			if attr.Key == AttrRunnableName {
+				name += " " + attr.Value.AsString()
+			}
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+type readyRunnable struct {
+	runy.ReadySignal
+}
+
+func (r *readyRunnable) Start(ctx context.Context) error {
+	r.SignalReady()
+	<-ctx.Done()
+	return nil
+}
+
+func TestTracer(t *testing.T) {
+	t.Run("start", func(t *testing.T) {
+		tr, exp := setupTest(t)
+		g := runy.NewGroup(runy.WithHooks(tr))
+		g.Add(runy.Named("db", &readyRunnable{}))
+		g.NextPhase()
+		g.Add(runy.Named("http", runy.RunnableFunc(func(ctx context.Context) error { return assert.AnError })))
+
+		require.ErrorIs(t, tr.Start(context.Background(), g), assert.AnError)
+
+		spans := exp.GetSpans()
+		assert.Equal(t, []string{
+			"runy.runnable.start db",
+			"runy.runnable.start http",
+			"runy.runnable.ready db",
+			"runy.runnable.stop db",
+			"runy.Group.Start",
+		}, names(spans))
+
+		root := spans[len(spans)-1]
+		assert.Equal(t, codes.Error, root.Status.Code)
+		for _, s := range spans[:len(spans)-1] {
+			assert.Equal(t, root.SpanContext.SpanID(), s.Parent.SpanID())
+			assert.Equal(t, root.SpanContext.TraceID(), s.SpanContext.TraceID())
+		}
+
+		httpStart := spans[1]
+		assert.Equal(t, codes.Error, httpStart.Status.Code)
+		if assert.Len(t, httpStart.Events, 1) {
+			assert.Equal(t, "exception", httpStart.Events[0].Name)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		tr, exp := setupTest(t)
+		g := runy.NewGroup(runy.WithHooks(tr))
+		g.Add(runy.Named("worker", runy.RunnableFunc(func(ctx context.Context) error { panic("boom") })))
+
+		var pErr *runy.PanicError
+		require.ErrorAs(t, g.Start(context.Background()), &pErr)
+
+		spans := exp.GetSpans()
+		assert.Equal(t, []string{"runy.runnable.start worker", "runy.Group.Start"}, names(spans))
+		var events []string
+		for _, ev := range spans[0].Events {
+			events = append(events, ev.Name)
+		}
+		assert.Equal(t, []string{"panic", "exception"}, events)
+		assert.Equal(t, codes.Error, spans[1].Status.Code)
+	})
+}
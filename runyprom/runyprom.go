@@ -0,0 +1,146 @@
+// Package runyprom provides a Prometheus implementation of runy.Metrics.
+//
+//	m := runyprom.New()
+//	prometheus.MustRegister(m)
+//	g := runy.NewGroup(runy.WithMetrics(m))
+package runyprom
+
+import (
+	"time"
+
+	"github.com/belo4ya/runy"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	_ runy.Metrics         = (*Metrics)(nil)
+	_ prometheus.Collector = (*Metrics)(nil)
+)
+
+// Metrics implements runy.Metrics with Prometheus collectors.
+// It's a prometheus.Collector itself, so it has to be registered to be exported.
+//
+// The following metrics are exported, prefixed with the namespace (see WithNamespace):
+//   - runnable_up: 1 if the Runnable is running, 0 otherwise;
+//   - runnable_restarts_total: restarts of the Runnable;
+//   - runnable_start_latency_seconds: time a ReadyNotifier took from its start until it was ready;
+//   - shutdown_duration_seconds: time the Group took to stop its Runnables.
+type Metrics struct {
+	up               *prometheus.GaugeVec
+	restarts         *prometheus.CounterVec
+	startLatency     *prometheus.HistogramVec
+	shutdownDuration prometheus.Histogram
+}
+
+// New creates Metrics with the provided options.
+func New(opts ...Option) *Metrics {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Metrics{
+		up: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   o.namespace,
+			Name:        "runnable_up",
+			Help:        "Whether the runnable is running (1) or not (0).",
+			ConstLabels: o.constLabels,
+		}, []string{"name"}),
+		restarts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Name:        "runnable_restarts_total",
+			Help:        "Total number of runnable restarts.",
+			ConstLabels: o.constLabels,
+		}, []string{"name"}),
+		startLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   o.namespace,
+			Name:        "runnable_start_latency_seconds",
+			Help:        "Time a runnable took from its start until it was ready.",
+			ConstLabels: o.constLabels,
+			Buckets:     o.buckets,
+		}, []string{"name"}),
+		shutdownDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace:   o.namespace,
+			Name:        "shutdown_duration_seconds",
+			Help:        "Time the group took to stop its runnables.",
+			ConstLabels: o.constLabels,
+			Buckets:     o.buckets,
+		}),
+	}
+}
+
+type options struct {
+	namespace   string
+	constLabels prometheus.Labels
+	buckets     []float64
+}
+
+func defaultOptions() options {
+	return options{
+		namespace: "runy",
+		buckets:   prometheus.DefBuckets,
+	}
+}
+
+// Option is a function that modifies the behavior of Metrics.
+type Option func(o *options)
+
+// WithNamespace sets the namespace of the metrics, "runy" by default.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithConstLabels sets labels added to all the metrics, e.g. to tell Groups apart.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(o *options) {
+		o.constLabels = labels
+	}
+}
+
+// WithBuckets sets the buckets of the latency and duration histograms, prometheus.DefBuckets by default.
+func WithBuckets(buckets []float64) Option {
+	return func(o *options) {
+		o.buckets = buckets
+	}
+}
+
+// SetUp implements runy.Metrics.
+func (m *Metrics) SetUp(name string, up bool) {
+	v := 0.0
+	if up {
+		v = 1
+	}
+	m.up.WithLabelValues(name).Set(v)
+}
+
+// IncRestarts implements runy.Metrics.
+func (m *Metrics) IncRestarts(name string) {
+	m.restarts.WithLabelValues(name).Inc()
+}
+
+// ObserveStartLatency implements runy.Metrics.
+func (m *Metrics) ObserveStartLatency(name string, d time.Duration) {
+	m.startLatency.WithLabelValues(name).Observe(d.Seconds())
+}
+
+// ObserveShutdownDuration implements runy.Metrics.
+func (m *Metrics) ObserveShutdownDuration(d time.Duration) {
+	m.shutdownDuration.Observe(d.Seconds())
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.up.Describe(ch)
+	m.restarts.Describe(ch)
+	m.startLatency.Describe(ch)
+	m.shutdownDuration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.up.Collect(ch)
+	m.restarts.Collect(ch)
+	m.startLatency.Collect(ch)
+	m.shutdownDuration.Collect(ch)
+}
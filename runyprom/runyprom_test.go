@@ -0,0 +1,68 @@
+package runyprom
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/belo4ya/runy"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New(WithConstLabels(prometheus.Labels{"app": "test"}))
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(m))
+
+	var starts int
+	g := runy.NewGroup(
+		runy.WithMetrics(m),
+		runy.WithStrategy(runy.OneForOne, runy.WithBackoff(runy.Backoff{Initial: time.Millisecond})),
+	)
+	g.Add(runy.Named("worker", runy.RunnableFunc(func(ctx context.Context) error {
+		if starts++; starts < 3 {
+			return assert.AnError
+		}
+		return nil
+	})))
+	require.NoError(t, g.Start(context.Background()))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	byName := make(map[string]*dto.MetricFamily, len(families))
+	for _, f := range families {
+		byName[f.GetName()] = f
+	}
+
+	if f := byName["runy_runnable_up"]; assert.NotNil(t, f) {
+		assert.Equal(t, 0.0, f.GetMetric()[0].GetGauge().GetValue())
+		assert.Equal(t, map[string]string{"app": "test", "name": "worker"}, labels(f.GetMetric()[0]))
+	}
+	if f := byName["runy_runnable_restarts_total"]; assert.NotNil(t, f) {
+		assert.Equal(t, 2.0, f.GetMetric()[0].GetCounter().GetValue())
+	}
+	if f := byName["runy_shutdown_duration_seconds"]; assert.NotNil(t, f) {
+		assert.Equal(t, uint64(1), f.GetMetric()[0].GetHistogram().GetSampleCount())
+	}
+	assert.Nil(t, byName["runy_runnable_start_latency_seconds"], "worker isn't a ReadyNotifier")
+
+	m.ObserveStartLatency("http", 100*time.Millisecond)
+	families, err = reg.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() == "runy_runnable_start_latency_seconds" {
+			assert.Equal(t, 0.1, f.GetMetric()[0].GetHistogram().GetSampleSum())
+		}
+	}
+}
+
+func labels(m *dto.Metric) map[string]string {
+	labels := make(map[string]string, len(m.GetLabel()))
+	for _, l := range m.GetLabel() {
+		labels[l.GetName()] = l.GetValue()
+	}
+	return labels
+}
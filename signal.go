@@ -9,22 +9,113 @@ import (
 
 var onlyOneSignalHandler = make(chan struct{})
 
+// osExit is replaced in tests.
+var osExit = os.Exit
+
 // SetupSignalHandler registers for SIGINT and SIGTERM.
 // A context is returned which is canceled on one of these signals.
 // If a second signal is caught, the program is terminated with exit code 1.
 func SetupSignalHandler() context.Context {
+	return SetupSignalHandlerWith()
+}
+
+// SetupSignalHandlerWith is like SetupSignalHandler, but the signals and what happens
+// on a second signal are configured with options, e.g. to stop gracefully on SIGQUIT:
+//
+//	ctx := runy.SetupSignalHandlerWith(runy.WithSignals(syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT))
+//
+// Like SetupSignalHandler, it panics when a signal handler has already been set up.
+func SetupSignalHandlerWith(opts ...SignalOption) context.Context {
+	o := defaultSignalOptions()
+	for _, opt := range opts {
+		opt.applySignal(&o)
+	}
+
 	close(onlyOneSignalHandler) // panics when called twice
 
+	ctx, _ := setupSignalHandler(o)
+	return ctx
+}
+
+// setupSignalHandler returns a context canceled on one of the signals
+// and a function that stops relaying the signals to the handler.
+func setupSignalHandler(o signalOptions) (context.Context, func()) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	c := make(chan os.Signal, 2)
-	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(c, o.signals...)
 	go func() {
 		<-c
 		cancel()
-		<-c
-		os.Exit(1) // second signal, exit directly
+		if !o.forceExit {
+			return // further signals are ignored
+		}
+		sig := <-c
+		if o.beforeExit != nil {
+			o.beforeExit(sig)
+		}
+		osExit(o.exitCode) // second signal, exit directly
 	}()
 
-	return ctx
+	return ctx, func() { signal.Stop(c) }
+}
+
+type signalOptions struct {
+	signals    []os.Signal
+	forceExit  bool
+	exitCode   int
+	beforeExit func(os.Signal)
+}
+
+func defaultSignalOptions() signalOptions {
+	return signalOptions{
+		signals:   []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		forceExit: true,
+		exitCode:  1,
+	}
+}
+
+// SignalOption modifies the behavior of SetupSignalHandlerWith.
+type SignalOption interface {
+	applySignal(o *signalOptions)
+}
+
+// signalOptionFunc is a SignalOption implemented by a function.
+type signalOptionFunc func(o *signalOptions)
+
+func (f signalOptionFunc) applySignal(o *signalOptions) {
+	f(o)
+}
+
+// WithSignals sets the signals the context is canceled on. The default is SIGINT and SIGTERM.
+// An empty set is ignored, as it would make the context canceled on any signal.
+func WithSignals(signals ...os.Signal) SignalOption {
+	return signalOptionFunc(func(o *signalOptions) {
+		if len(signals) > 0 {
+			o.signals = signals
+		}
+	})
+}
+
+// WithForceExit sets whether a second signal terminates the program. The default is true.
+// Otherwise, the signals caught after the first one are ignored.
+func WithForceExit(force bool) SignalOption {
+	return signalOptionFunc(func(o *signalOptions) {
+		o.forceExit = force
+	})
+}
+
+// WithExitCode sets the exit code the program is terminated with on a second signal. The default is 1.
+func WithExitCode(code int) SignalOption {
+	return signalOptionFunc(func(o *signalOptions) {
+		o.exitCode = code
+	})
+}
+
+// WithBeforeExit sets a function that is called with the second signal before the program is terminated,
+// e.g. to flush logs. The program is terminated once the function returns.
+func WithBeforeExit(fn func(sig os.Signal)) SignalOption {
+	return signalOptionFunc(func(o *signalOptions) {
+		o.beforeExit = fn
+	})
 }
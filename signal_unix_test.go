@@ -0,0 +1,79 @@
+//go:build unix
+
+package runy
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupSignalHandlerWith(t *testing.T) {
+	// setup sets up a handler with the options and returns a function that sends SIGUSR2
+	// to the process and waits until it's delivered.
+	setup := func(t *testing.T, opts ...SignalOption) (context.Context, func()) {
+		o := defaultSignalOptions()
+		for _, opt := range append([]SignalOption{WithSignals(syscall.SIGUSR2)}, opts...) {
+			opt.applySignal(&o)
+		}
+		ctx, stop := setupSignalHandler(o)
+		delivered := make(chan os.Signal, 1)
+		signal.Notify(delivered, syscall.SIGUSR2)
+		t.Cleanup(func() {
+			stop()
+			signal.Stop(delivered)
+		})
+		return ctx, func() {
+			assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR2))
+			<-delivered
+		}
+	}
+
+	t.Run("force exit", func(t *testing.T) {
+		exited := make(chan int, 1)
+		osExit = func(code int) { exited <- code }
+		t.Cleanup(func() { osExit = os.Exit })
+
+		var beforeExit os.Signal
+		ctx, kill := setup(t, WithExitCode(3), WithBeforeExit(func(sig os.Signal) { beforeExit = sig }))
+
+		kill()
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			assert.Fail(t, "context not canceled on the first signal")
+		}
+
+		kill()
+		select {
+		case code := <-exited:
+			assert.Equal(t, 3, code)
+			assert.Equal(t, syscall.SIGUSR2, beforeExit)
+		case <-time.After(time.Second):
+			assert.Fail(t, "not exited on the second signal")
+		}
+	})
+
+	t.Run("no force exit", func(t *testing.T) {
+		ctx, kill := setup(t, WithForceExit(false), WithBeforeExit(func(os.Signal) { assert.Fail(t, "before exit called") }))
+
+		kill()
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			assert.Fail(t, "context not canceled on the first signal")
+		}
+		kill() // ignored
+	})
+
+	t.Run("empty signals", func(t *testing.T) {
+		o := defaultSignalOptions()
+		WithSignals().applySignal(&o)
+		assert.Equal(t, []os.Signal{syscall.SIGINT, syscall.SIGTERM}, o.signals)
+	})
+}
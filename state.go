@@ -0,0 +1,98 @@
+package runy
+
+import (
+	"fmt"
+	"time"
+)
+
+// GroupState is a stage of the Group lifecycle.
+type GroupState int
+
+const (
+	// StateCreated is the state of a Group that has never been started.
+	StateCreated GroupState = iota
+	// StateStarting is the state of a Group whose Runnables are being started
+	// and aren't all ready yet (see Group.Ready).
+	StateStarting
+	// StateRunning is the state of a Group whose Runnables are all ready.
+	StateRunning
+	// StateStopping is the state of a Group whose Runnables are being stopped.
+	StateStopping
+	// StateStopped is the state of a Group whose Start has returned.
+	// A stopped Group can be started again.
+	StateStopped
+)
+
+func (s GroupState) String() string {
+	switch s {
+	case StateCreated:
+		return "created"
+	case StateStarting:
+		return "starting"
+	case StateRunning:
+		return "running"
+	case StateStopping:
+		return "stopping"
+	case StateStopped:
+		return "stopped"
+	default:
+		return fmt.Sprintf("GroupState(%d)", int(s))
+	}
+}
+
+// RunnableState is a stage of the Runnable lifecycle in a Group.
+type RunnableState int
+
+const (
+	// RunnablePending is the state of a Runnable that isn't started yet,
+	// e.g. because the Group isn't started or its dependencies aren't ready.
+	RunnablePending RunnableState = iota
+	// RunnableStarting is the state of a started ReadyNotifier that isn't ready yet.
+	RunnableStarting
+	// RunnableReady is the state of a started ReadyNotifier that is ready.
+	RunnableReady
+	// RunnableRunning is the state of a started Runnable that isn't a ReadyNotifier.
+	RunnableRunning
+	// RunnableStopping is the state of a Runnable whose context has been canceled by the Group.
+	RunnableStopping
+	// RunnableStopped is the state of a Runnable that has returned without an error.
+	RunnableStopped
+	// RunnableFailed is the state of a Runnable that has returned with an error or has been abandoned.
+	RunnableFailed
+)
+
+func (s RunnableState) String() string {
+	switch s {
+	case RunnablePending:
+		return "pending"
+	case RunnableStarting:
+		return "starting"
+	case RunnableReady:
+		return "ready"
+	case RunnableRunning:
+		return "running"
+	case RunnableStopping:
+		return "stopping"
+	case RunnableStopped:
+		return "stopped"
+	case RunnableFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("RunnableState(%d)", int(s))
+	}
+}
+
+// RunnableStatus is a snapshot of the status of a Runnable in a Group (see Group.Status).
+type RunnableStatus struct {
+	// Name is the name of the Runnable (see Named).
+	Name string
+	// State is the lifecycle state of the Runnable.
+	State RunnableState
+	// StartedAt is when the Runnable was last started, zero if it hasn't been started.
+	StartedAt time.Time
+	// Restarts is how many times the Runnable has been restarted during the current or the last Start,
+	// by the Strategy of the Group or by Supervise.
+	Restarts int
+	// LastError is the last error the Runnable failed with, if any.
+	LastError error
+}
@@ -0,0 +1,255 @@
+package runy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupState(t *testing.T) {
+	t.Run("lifecycle", func(t *testing.T) {
+		setupTest(t)
+
+		assert.Equal(t, StateCreated, State())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		rn := newReadyRunnable()
+		stopping := make(chan struct{})
+		release := make(chan struct{})
+		Add(rn)
+		AddF(func(ctx context.Context) error {
+			<-ctx.Done()
+			close(stopping)
+			<-release
+			return nil
+		})
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+
+		assert.Eventually(t, func() bool { return State() == StateStarting }, time.Second, time.Millisecond)
+		close(rn.ready)
+		assert.NoError(t, WaitReady(ctx))
+		assert.Equal(t, StateRunning, State())
+
+		cancel()
+		<-stopping
+		assert.Equal(t, StateStopping, State())
+		close(release)
+		assert.NoError(t, <-errCh)
+		assert.Equal(t, StateStopped, State())
+	})
+
+	t.Run("start twice", func(t *testing.T) {
+		setupTest(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		rn := newReadyRunnable()
+		close(rn.ready)
+		Add(rn)
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+		assert.NoError(t, WaitReady(ctx))
+
+		assert.ErrorIs(t, Start(ctx), ErrGroupStarted)
+
+		cancel()
+		assert.NoError(t, <-errCh)
+		assert.NoError(t, Start(ctx), "a stopped group can be started again")
+	})
+
+	t.Run("add while stopping", func(t *testing.T) {
+		setupTest(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stopping := make(chan struct{})
+		release := make(chan struct{})
+		AddF(func(ctx context.Context) error {
+			<-ctx.Done()
+			close(stopping)
+			<-release
+			return nil
+		})
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+		assert.NoError(t, WaitReady(ctx))
+
+		cancel()
+		<-stopping
+		_, err := Spawn(RunnableFunc(func(ctx context.Context) error { return nil }))
+		assert.ErrorIs(t, err, ErrGroupStopping)
+		AddF(func(ctx context.Context) error { return nil })
+		close(release)
+		assert.ErrorIs(t, <-errCh, ErrGroupStopping)
+	})
+}
+
+func TestGroupState_String(t *testing.T) {
+	assert.Equal(t, "created", StateCreated.String())
+	assert.Equal(t, "starting", StateStarting.String())
+	assert.Equal(t, "running", StateRunning.String())
+	assert.Equal(t, "stopping", StateStopping.String())
+	assert.Equal(t, "stopped", StateStopped.String())
+	assert.Equal(t, "GroupState(42)", GroupState(42).String())
+}
+
+func TestGroupReset(t *testing.T) {
+	t.Run("run again", func(t *testing.T) {
+		setupTest(t)
+
+		var starts int
+		AddF(func(ctx context.Context) error {
+			starts++
+			return nil
+		})
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, Start(context.Background()))
+		}
+		assert.Equal(t, 3, starts)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		g := setupTest(t)
+
+		AddF(func(ctx context.Context) error { return nil })
+		NextPhase()
+		AddF(func(ctx context.Context) error { return nil })
+		assert.NoError(t, Start(context.Background()))
+		assert.Equal(t, StateStopped, State())
+
+		assert.NoError(t, Reset())
+		assert.Equal(t, StateCreated, State())
+		assert.Empty(t, g.entries)
+		AddF(func(ctx context.Context) error { return nil })
+		assert.Equal(t, "runy.RunnableFunc#0", g.entries[0].name)
+		assert.Equal(t, 0, g.entries[0].phase)
+	})
+
+	t.Run("reset while started", func(t *testing.T) {
+		setupTest(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		rn := newReadyRunnable()
+		close(rn.ready)
+		Add(rn)
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+		assert.NoError(t, WaitReady(ctx))
+
+		assert.ErrorIs(t, Reset(), ErrGroupStarted)
+
+		cancel()
+		assert.NoError(t, <-errCh)
+	})
+}
+
+func TestGroupStatus(t *testing.T) {
+	t.Run("states", func(t *testing.T) {
+		setupTest(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		notifier := newReadyRunnable()
+		stopping := make(chan struct{})
+		release := make(chan struct{})
+		Add(Named("notifier", notifier))
+		AddF(func(ctx context.Context) error {
+			<-ctx.Done()
+			close(stopping)
+			<-release
+			return assert.AnError
+		})
+		NextPhase()
+		Add(Named("pending", RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})))
+
+		states := func() []RunnableState {
+			var states []RunnableState
+			for _, s := range Status() {
+				states = append(states, s.State)
+			}
+			return states
+		}
+		assert.Equal(t, []RunnableState{RunnablePending, RunnablePending, RunnablePending}, states())
+
+		errCh := make(chan error, 1)
+		go func() { errCh <- Start(ctx) }()
+		assert.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual([]RunnableState{RunnableStarting, RunnableRunning, RunnablePending}, states())
+		}, time.Second, time.Millisecond)
+		status := Status()
+		assert.Equal(t, "notifier", status[0].Name)
+		assert.False(t, status[0].StartedAt.IsZero())
+		assert.True(t, status[2].StartedAt.IsZero())
+
+		close(notifier.ready)
+		assert.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual([]RunnableState{RunnableReady, RunnableRunning, RunnablePending}, states())
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		<-stopping
+		assert.Equal(t, RunnableStopping, Status()[1].State)
+		close(release)
+		assert.ErrorIs(t, <-errCh, assert.AnError)
+
+		status = Status()
+		assert.Equal(t, []RunnableState{RunnableStopped, RunnableFailed, RunnablePending}, states())
+		assert.ErrorIs(t, status[1].LastError, assert.AnError)
+		assert.NoError(t, status[0].LastError)
+	})
+
+	t.Run("restarts", func(t *testing.T) {
+		g := NewGroup(WithStrategy(OneForOne, WithBackoff(Backoff{Initial: time.Millisecond})))
+
+		var strategyStarts, superviseStarts int
+		g.Add(Named("strategy", RunnableFunc(func(ctx context.Context) error {
+			if strategyStarts++; strategyStarts < 3 {
+				return assert.AnError
+			}
+			<-ctx.Done()
+			return nil
+		})))
+		g.Add(Named("supervise", Supervise(RunnableFunc(func(ctx context.Context) error {
+			if superviseStarts++; superviseStarts < 4 {
+				return assert.AnError
+			}
+			<-ctx.Done()
+			return nil
+		}), WithBackoff(Backoff{Initial: time.Millisecond}))))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		errCh := make(chan error, 1)
+		go func() { errCh <- g.Start(ctx) }()
+		assert.Eventually(t, func() bool {
+			status := g.Status()
+			return status[0].Restarts == 2 && status[1].Restarts == 3
+		}, time.Second, time.Millisecond)
+		for _, s := range g.Status() {
+			assert.Equal(t, RunnableRunning, s.State)
+			assert.ErrorIs(t, s.LastError, assert.AnError)
+		}
+
+		cancel()
+		assert.NoError(t, <-errCh)
+	})
+}
+
+func TestRunnableState_String(t *testing.T) {
+	assert.Equal(t, "pending", RunnablePending.String())
+	assert.Equal(t, "starting", RunnableStarting.String())
+	assert.Equal(t, "ready", RunnableReady.String())
+	assert.Equal(t, "running", RunnableRunning.String())
+	assert.Equal(t, "stopping", RunnableStopping.String())
+	assert.Equal(t, "stopped", RunnableStopped.String())
+	assert.Equal(t, "failed", RunnableFailed.String())
+	assert.Equal(t, "RunnableState(42)", RunnableState(42).String())
+}
@@ -0,0 +1,226 @@
+package runy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ErrRestartsExhausted is returned by a supervised Runnable when it can't be restarted anymore
+// because the maximum number of restarts has been reached (see WithMaxRestarts).
+var ErrRestartsExhausted = errors.New("restarts exhausted")
+
+// RestartPolicy defines when a supervised Runnable is restarted.
+type RestartPolicy int
+
+const (
+	// RestartNever never restarts the Runnable.
+	RestartNever RestartPolicy = iota
+	// RestartAlways restarts the Runnable whenever it returns, even without an error.
+	RestartAlways
+	// RestartOnFailure restarts the Runnable only when it returns an error.
+	RestartOnFailure
+)
+
+func (p RestartPolicy) String() string {
+	switch p {
+	case RestartNever:
+		return "never"
+	case RestartAlways:
+		return "always"
+	case RestartOnFailure:
+		return "on-failure"
+	default:
+		return fmt.Sprintf("RestartPolicy(%d)", int(p))
+	}
+}
+
+func (p RestartPolicy) shouldRestart(err error) bool {
+	switch p {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return err != nil
+	default:
+		return false
+	}
+}
+
+// Strategy defines how a Group reacts to a failure of one of its Runnables, similar to Erlang supervisors.
+// The restarts are limited by the restart policy, backoff and maximum restarts of the Group (see WithStrategy).
+type Strategy int
+
+const (
+	// OneForOne restarts only the failed Runnable.
+	OneForOne Strategy = iota + 1
+	// OneForAll stops all the other Runnables in reverse registration order and restarts all of them.
+	OneForAll
+	// RestForOne stops the Runnables registered after the failed one in reverse registration order
+	// and restarts the failed Runnable along with them.
+	RestForOne
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case OneForOne:
+		return "one-for-one"
+	case OneForAll:
+		return "one-for-all"
+	case RestForOne:
+		return "rest-for-one"
+	default:
+		return fmt.Sprintf("Strategy(%d)", int(s))
+	}
+}
+
+// WithStrategy makes a Group restart its Runnables according to the strategy instead of shutting down
+// when one of them fails. The restarts are configured with the same options as for Supervise
+// and are counted for the Group as a whole. Once the restarts are exhausted, the Group shuts down.
+// Restarted Runnables must support being started again.
+// Runnables created by FromCleanup and FromCloser aren't restarted along with their siblings.
+func WithStrategy(strategy Strategy, opts ...SuperviseOption) GroupOption {
+	return groupOptionFunc(func(o *groupOptions) {
+		o.strategy = strategy
+		o.supervise = defaultSuperviseOptions()
+		for _, opt := range opts {
+			opt(&o.supervise)
+		}
+	})
+}
+
+// Backoff configures the exponential backoff between restarts of a supervised Runnable.
+// The delay before the n-th restart within the restarts window is Initial * Multiplier^(n-1),
+// capped at Max and randomized by Jitter.
+type Backoff struct {
+	// Initial is the delay before the first restart. Zero means restarting immediately.
+	Initial time.Duration
+	// Max is the upper bound of the delay. Zero means no bound.
+	Max time.Duration
+	// Multiplier is the factor the delay grows by after each restart. Values less than 1 are treated as 1.
+	Multiplier float64
+	// Jitter randomizes the delay: it's picked from [d*(1-Jitter), d*(1+Jitter)].
+	// Values are clamped to [0, 1].
+	Jitter float64
+}
+
+// delay returns the delay before a restart given the number of previous restarts.
+func (b Backoff) delay(restarts int) time.Duration {
+	if b.Initial <= 0 {
+		return 0
+	}
+	mult := math.Max(b.Multiplier, 1)
+	d := float64(b.Initial) * math.Pow(mult, float64(restarts))
+	if b.Max > 0 {
+		d = math.Min(d, float64(b.Max))
+	}
+	jitter := math.Min(math.Max(b.Jitter, 0), 1)
+	d *= 1 + jitter*(2*rand.Float64()-1)
+	return time.Duration(d)
+}
+
+// Supervise returns a Runnable that restarts rn according to the restart policy,
+// RestartOnFailure by default. The error of rn is returned only when it's not restarted anymore,
+// e.g. the restarts are exhausted (see WithMaxRestarts). Nothing is restarted once the context is canceled.
+func Supervise(rn Runnable, opts ...SuperviseOption) Runnable {
+	o := defaultSuperviseOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &supervisor{rn: rn, opts: o}
+}
+
+type superviseOptions struct {
+	policy        RestartPolicy
+	backoff       Backoff
+	maxRestarts   int
+	restartWindow time.Duration
+}
+
+func defaultSuperviseOptions() superviseOptions {
+	return superviseOptions{
+		policy: RestartOnFailure,
+		backoff: Backoff{
+			Initial:    100 * time.Millisecond,
+			Max:        10 * time.Second,
+			Multiplier: 2,
+			Jitter:     0.2,
+		},
+	}
+}
+
+// SuperviseOption is a function that modifies the behavior of Supervise.
+type SuperviseOption func(o *superviseOptions)
+
+// WithRestartPolicy sets the restart policy of a supervised Runnable.
+func WithRestartPolicy(policy RestartPolicy) SuperviseOption {
+	return func(o *superviseOptions) {
+		o.policy = policy
+	}
+}
+
+// WithBackoff sets the backoff between restarts of a supervised Runnable.
+// By default, the delay starts at 100ms and doubles up to 10s with 20% jitter.
+func WithBackoff(backoff Backoff) SuperviseOption {
+	return func(o *superviseOptions) {
+		o.backoff = backoff
+	}
+}
+
+// WithMaxRestarts limits a supervised Runnable to maxRestarts restarts within the sliding window.
+// When the limit is reached, the Runnable isn't restarted anymore and its error is returned
+// wrapped with ErrRestartsExhausted. A zero window means the limit applies to the whole run.
+// By default, the number of restarts is unlimited.
+func WithMaxRestarts(maxRestarts int, window time.Duration) SuperviseOption {
+	return func(o *superviseOptions) {
+		o.maxRestarts = maxRestarts
+		o.restartWindow = window
+	}
+}
+
+type supervisor struct {
+	rn   Runnable
+	opts superviseOptions
+}
+
+func (s *supervisor) Start(ctx context.Context) error {
+	var restarts []time.Time // restarts within the window
+	hook := restartHook(ctx)
+	for {
+		err := s.rn.Start(ctx)
+		if ctx.Err() != nil || errors.Is(err, ErrStopGroup) || !s.opts.policy.shouldRestart(err) {
+			return err
+		}
+
+		now := time.Now()
+		if s.opts.restartWindow > 0 {
+			for len(restarts) > 0 && now.Sub(restarts[0]) > s.opts.restartWindow {
+				restarts = restarts[1:]
+			}
+		}
+		if s.opts.maxRestarts > 0 && len(restarts) >= s.opts.maxRestarts {
+			if err == nil {
+				return fmt.Errorf("%w: %d restarts", ErrRestartsExhausted, len(restarts))
+			}
+			return fmt.Errorf("%w: %d restarts: %w", ErrRestartsExhausted, len(restarts), err)
+		}
+
+		t := time.NewTimer(s.opts.backoff.delay(len(restarts)))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+		restarts = append(restarts, now)
+		if hook != nil {
+			hook(err)
+		}
+	}
+}
+
+func (s *supervisor) Unwrap() Runnable {
+	return s.rn
+}
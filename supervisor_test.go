@@ -0,0 +1,447 @@
+package runy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupervise(t *testing.T) {
+	noBackoff := WithBackoff(Backoff{})
+
+	// countingRunnable returns the results in order, then blocks until the context is canceled.
+	countingRunnable := func(calls *int, results ...error) RunnableFunc {
+		return func(ctx context.Context) error {
+			*calls++
+			if *calls <= len(results) {
+				return results[*calls-1]
+			}
+			<-ctx.Done()
+			return nil
+		}
+	}
+
+	t.Run("policies", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			policy    RestartPolicy
+			results   []error
+			wantCalls int
+			wantErr   error
+		}{
+			{
+				name:      "never restarts on failure",
+				policy:    RestartNever,
+				results:   []error{assert.AnError},
+				wantCalls: 1,
+				wantErr:   assert.AnError,
+			},
+			{
+				name:      "on failure restarts on failure",
+				policy:    RestartOnFailure,
+				results:   []error{assert.AnError, assert.AnError, nil},
+				wantCalls: 3,
+				wantErr:   nil,
+			},
+			{
+				name:      "always restarts on success",
+				policy:    RestartAlways,
+				results:   []error{nil, assert.AnError},
+				wantCalls: 3,
+				wantErr:   nil,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				var calls int
+				sup := Supervise(RunnableFunc(func(ctx context.Context) error {
+					calls++
+					if calls <= len(tt.results) {
+						return tt.results[calls-1]
+					}
+					cancel()
+					return nil
+				}), WithRestartPolicy(tt.policy), noBackoff)
+
+				err := sup.Start(ctx)
+				assert.Equal(t, tt.wantCalls, calls)
+				assert.Equal(t, tt.wantErr, err)
+			})
+		}
+	})
+
+	t.Run("max restarts", func(t *testing.T) {
+		var calls int
+		sup := Supervise(RunnableFunc(func(ctx context.Context) error {
+			calls++
+			return assert.AnError
+		}), WithMaxRestarts(3, time.Minute), noBackoff)
+
+		err := sup.Start(context.Background())
+		assert.Equal(t, 4, calls)
+		assert.ErrorIs(t, err, ErrRestartsExhausted)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("max restarts window", func(t *testing.T) {
+		var calls int
+		sup := Supervise(RunnableFunc(func(ctx context.Context) error {
+			calls++
+			if calls == 3 {
+				time.Sleep(60 * time.Millisecond) // old restarts leave the window
+			}
+			return assert.AnError
+		}), WithMaxRestarts(2, 50*time.Millisecond), noBackoff)
+
+		err := sup.Start(context.Background())
+		assert.Equal(t, 5, calls)
+		assert.ErrorIs(t, err, ErrRestartsExhausted)
+	})
+
+	t.Run("max restarts without error", func(t *testing.T) {
+		sup := Supervise(RunnableFunc(func(ctx context.Context) error {
+			return nil
+		}), WithRestartPolicy(RestartAlways), WithMaxRestarts(1, 0), noBackoff)
+
+		assert.ErrorIs(t, sup.Start(context.Background()), ErrRestartsExhausted)
+	})
+
+	t.Run("canceled during backoff", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		var calls int
+		sup := Supervise(RunnableFunc(func(ctx context.Context) error {
+			calls++
+			return assert.AnError
+		}), WithBackoff(Backoff{Initial: time.Minute}))
+
+		start := time.Now()
+		assert.ErrorIs(t, sup.Start(ctx), assert.AnError)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("in group", func(t *testing.T) {
+		setupTest(t)
+
+		var calls int
+		Add(Named("worker", Supervise(countingRunnable(&calls, assert.AnError, assert.AnError), noBackoff)))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.NoError(t, Start(ctx))
+		assert.Equal(t, 3, calls)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+	assert.Equal(t, 100*time.Millisecond, b.delay(0))
+	assert.Equal(t, 200*time.Millisecond, b.delay(1))
+	assert.Equal(t, 800*time.Millisecond, b.delay(3))
+	assert.Equal(t, time.Second, b.delay(10))
+
+	b.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := b.delay(1)
+		assert.GreaterOrEqual(t, d, 100*time.Millisecond)
+		assert.LessOrEqual(t, d, 300*time.Millisecond)
+	}
+
+	assert.Equal(t, time.Duration(0), Backoff{}.delay(5))
+	assert.Equal(t, 100*time.Millisecond, Backoff{Initial: 100 * time.Millisecond}.delay(5))
+}
+
+func TestRestartPolicy_String(t *testing.T) {
+	assert.Equal(t, "never", RestartNever.String())
+	assert.Equal(t, "always", RestartAlways.String())
+	assert.Equal(t, "on-failure", RestartOnFailure.String())
+	assert.Equal(t, "RestartPolicy(42)", RestartPolicy(42).String())
+}
+
+func TestStrategy(t *testing.T) {
+	noBackoff := WithBackoff(Backoff{})
+
+	// recorder records starts and stops of the Runnables by name.
+	type recorder struct {
+		mu     sync.Mutex
+		events []string
+	}
+	record := func(rec *recorder, event string) {
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		rec.events = append(rec.events, event)
+	}
+	// failing returns a Runnable that fails on the first start and then blocks until the context is canceled.
+	failing := func(rec *recorder, name string) RunnableFunc {
+		var starts int
+		return func(ctx context.Context) error {
+			starts++
+			record(rec, "start "+name)
+			if starts == 1 {
+				time.Sleep(10 * time.Millisecond)
+				return assert.AnError
+			}
+			<-ctx.Done()
+			record(rec, "stop "+name)
+			return nil
+		}
+	}
+	blocking := func(rec *recorder, name string) RunnableFunc {
+		return func(ctx context.Context) error {
+			record(rec, "start "+name)
+			<-ctx.Done()
+			record(rec, "stop "+name)
+			return nil
+		}
+	}
+	// ready makes the Runnable ready as soon as it's started.
+	ready := func(rn RunnableFunc) Runnable {
+		r := newReadyRunnableFunc(rn)
+		close(r.ready)
+		return r
+	}
+
+	tests := []struct {
+		strategy   Strategy
+		wantStarts map[string]int
+		wantStops  []string
+	}{
+		{
+			strategy:   OneForOne,
+			wantStarts: map[string]int{"a": 1, "b": 2, "c": 1},
+			wantStops:  []string{"c", "b", "a"},
+		},
+		{
+			strategy:   OneForAll,
+			wantStarts: map[string]int{"a": 2, "b": 2, "c": 2},
+			wantStops:  []string{"c", "a", "c", "b", "a"},
+		},
+		{
+			strategy:   RestForOne,
+			wantStarts: map[string]int{"a": 1, "b": 2, "c": 2},
+			wantStops:  []string{"c", "c", "b", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy.String(), func(t *testing.T) {
+			rec := &recorder{}
+			g := NewGroup(WithStrategy(tt.strategy, noBackoff))
+			// Phases make the registration order the start order.
+			g.Add(ready(blocking(rec, "a"))).NextPhase()
+			g.Add(ready(failing(rec, "b"))).NextPhase()
+			g.Add(ready(blocking(rec, "c")))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			assert.NoError(t, g.Start(ctx))
+
+			var stops []string
+			for name, want := range tt.wantStarts {
+				assert.Equal(t, want, count(rec.events, "start "+name), "starts of %s", name)
+			}
+			for _, e := range rec.events {
+				if strings.HasPrefix(e, "stop ") {
+					stops = append(stops, strings.TrimPrefix(e, "stop "))
+				}
+			}
+			assert.Equal(t, tt.wantStops, stops)
+		})
+	}
+
+	t.Run("stuck sibling", func(t *testing.T) {
+		g := NewGroup(WithStrategy(OneForAll, noBackoff), WithShutdownTimeout(100*time.Millisecond))
+		release := make(chan struct{})
+		defer close(release)
+		g.Add(Named("stuck", RunnableFunc(func(ctx context.Context) error {
+			<-release
+			return nil
+		})))
+		g.AddF(func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return assert.AnError
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		errCh := make(chan error, 1)
+		go func() { errCh <- g.Start(ctx) }()
+		select {
+		case err := <-errCh:
+			var stErr *ShutdownTimeoutError
+			if assert.ErrorAs(t, err, &stErr) {
+				assert.Equal(t, []string{"stuck"}, stErr.Running)
+			}
+		case <-time.After(time.Second):
+			assert.Fail(t, "group blocked by the stuck sibling during the restart")
+		}
+	})
+
+	t.Run("spawn during restart", func(t *testing.T) {
+		g := NewGroup(WithStrategy(OneForAll, noBackoff))
+		var mu sync.Mutex
+		var dispatches, tenantStarts int
+		g.Add(Named("dispatcher", RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			mu.Lock()
+			dispatches++
+			mu.Unlock()
+			// Spawn while the Group stops the dispatcher to restart it.
+			_, err := g.Spawn(RunnableFunc(func(ctx context.Context) error {
+				mu.Lock()
+				tenantStarts++
+				mu.Unlock()
+				<-ctx.Done()
+				return nil
+			}))
+			if err != nil && !errors.Is(err, ErrGroupStopping) {
+				return err
+			}
+			return nil
+		})))
+		var starts int
+		g.AddF(func(ctx context.Context) error {
+			if starts++; starts == 1 {
+				time.Sleep(10 * time.Millisecond)
+				return assert.AnError
+			}
+			<-ctx.Done()
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		errCh := make(chan error, 1)
+		go func() { errCh <- g.Start(ctx) }()
+		select {
+		case err := <-errCh:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			assert.Fail(t, "group blocked by the spawn during the restart")
+			return
+		}
+		assert.Equal(t, 2, starts)
+		assert.Equal(t, 2, dispatches)
+		assert.GreaterOrEqual(t, tenantStarts, 1)
+	})
+
+	t.Run("stop handle during backoff", func(t *testing.T) {
+		g := NewGroup(WithStrategy(OneForOne, WithBackoff(Backoff{Initial: time.Second})))
+		g.AddF(func(ctx context.Context) error { return assert.AnError })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		errCh := make(chan error, 1)
+		go func() { errCh <- g.Start(ctx) }()
+
+		started := make(chan struct{})
+		h, err := g.Spawn(RunnableFunc(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return nil
+		}))
+		if !assert.NoError(t, err) {
+			return
+		}
+		<-started
+		time.Sleep(10 * time.Millisecond) // the failing Runnable is in its backoff
+
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer stopCancel()
+		begin := time.Now()
+		assert.NoError(t, h.Stop(stopCtx))
+		assert.Less(t, time.Since(begin), 100*time.Millisecond)
+
+		cancel()
+		assert.NoError(t, <-errCh)
+	})
+
+	t.Run("restarts exhausted", func(t *testing.T) {
+		g := NewGroup(WithStrategy(OneForOne, WithMaxRestarts(2, 0), noBackoff))
+		var starts int
+		g.Add(Named("flaky", RunnableFunc(func(ctx context.Context) error {
+			starts++
+			return assert.AnError
+		})))
+
+		err := g.Start(context.Background())
+		assert.Equal(t, 3, starts)
+		assert.ErrorIs(t, err, ErrRestartsExhausted)
+		assert.ErrorIs(t, err, assert.AnError)
+		var rnErr *RunnableError
+		if assert.ErrorAs(t, err, &rnErr) {
+			assert.Equal(t, "flaky", rnErr.Name)
+		}
+	})
+
+	t.Run("restart policy", func(t *testing.T) {
+		g := NewGroup(WithStrategy(OneForOne, WithRestartPolicy(RestartNever)))
+		g.AddF(func(ctx context.Context) error { return assert.AnError })
+		assert.ErrorIs(t, g.Start(context.Background()), assert.AnError)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		rec := &recorder{}
+		dataPlane := NewGroup(WithStrategy(OneForAll, noBackoff))
+		dataPlane.AddF(blocking(rec, "consumer"), failing(rec, "producer"))
+
+		app := NewGroup()
+		app.AddF(blocking(rec, "http"))
+		app.Add(Named("data-plane", dataPlane))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		assert.NoError(t, app.Start(ctx))
+		assert.Equal(t, 1, count(rec.events, "start http"))
+		assert.Equal(t, 2, count(rec.events, "start consumer"))
+		assert.Equal(t, 2, count(rec.events, "start producer"))
+	})
+
+	t.Run("nested restart", func(t *testing.T) {
+		var starts int
+		inner := NewGroup()
+		inner.AddF(func(ctx context.Context) error {
+			starts++
+			if starts == 1 {
+				return assert.AnError
+			}
+			<-ctx.Done()
+			return nil
+		})
+
+		app := NewGroup(WithStrategy(OneForOne, noBackoff))
+		app.Add(Named("inner", inner))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.NoError(t, app.Start(ctx))
+		assert.Equal(t, 2, starts)
+	})
+}
+
+func count(events []string, event string) int {
+	var n int
+	for _, e := range events {
+		if e == event {
+			n++
+		}
+	}
+	return n
+}
+
+func TestStrategy_String(t *testing.T) {
+	assert.Equal(t, "one-for-one", OneForOne.String())
+	assert.Equal(t, "one-for-all", OneForAll.String())
+	assert.Equal(t, "rest-for-one", RestForOne.String())
+	assert.Equal(t, "Strategy(0)", Strategy(0).String())
+}
@@ -0,0 +1,83 @@
+package runy
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime/pprof"
+	"strings"
+	"time"
+)
+
+type watchdogOptions struct {
+	grace time.Duration
+	open  func() (io.WriteCloser, error)
+}
+
+// WithShutdownWatchdog makes the Group write diagnostics to w if its shutdown takes longer than grace:
+// the names of the Runnables that haven't returned yet, followed by the stacks of all goroutines
+// (the goroutine profile of runtime/pprof). Unlike WithShutdownTimeout, the Group keeps waiting
+// for its Runnables after the diagnostics are written. A non-positive grace disables the watchdog.
+func WithShutdownWatchdog(grace time.Duration, w io.Writer) GroupOption {
+	return groupOptionFunc(func(o *groupOptions) {
+		o.watchdog = watchdogOptions{
+			grace: grace,
+			open:  func() (io.WriteCloser, error) { return nopWriteCloser{w}, nil },
+		}
+	})
+}
+
+// WithShutdownWatchdogFile is like WithShutdownWatchdog, but the diagnostics are written to the file
+// with the given name. The file is created, or truncated, only when the diagnostics are written.
+func WithShutdownWatchdogFile(grace time.Duration, name string) GroupOption {
+	return groupOptionFunc(func(o *groupOptions) {
+		o.watchdog = watchdogOptions{
+			grace: grace,
+			open:  func() (io.WriteCloser, error) { return os.Create(name) },
+		}
+	})
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// dumpDiagnostics writes the shutdown diagnostics (see WithShutdownWatchdog).
+// Failures to write them are logged, if there is a logger.
+func (r *run) dumpDiagnostics() {
+	var running []string
+	for _, u := range r.units {
+		if u.started && !u.exited {
+			running = append(running, u.name)
+		}
+	}
+	if r.opts.logger != nil {
+		r.opts.logger.Warn("shutdown watchdog fired", "grace", r.opts.watchdog.grace, "running", running)
+	}
+
+	if err := writeDiagnostics(r.opts.watchdog, running); err != nil && r.opts.logger != nil {
+		r.opts.logger.Error("failed to write shutdown diagnostics", "error", err)
+	}
+}
+
+func writeDiagnostics(o watchdogOptions, running []string) (err error) {
+	w, err := o.open()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := w.Close(); err == nil {
+			err = cErr
+		}
+	}()
+
+	if _, err := fmt.Fprintf(w, "shutdown hasn't completed within %s, still running: %s\n\n",
+		o.grace, strings.Join(running, ", ")); err != nil {
+		return err
+	}
+	return pprof.Lookup("goroutine").WriteTo(w, 2)
+}
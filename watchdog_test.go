@@ -0,0 +1,72 @@
+package runy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownWatchdog(t *testing.T) {
+	slow := func(d time.Duration) RunnableFunc {
+		return func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(d)
+			return nil
+		}
+	}
+
+	t.Run("writer", func(t *testing.T) {
+		var buf bytes.Buffer
+		g := NewGroup(WithShutdownWatchdog(20*time.Millisecond, &buf))
+		g.Add(Named("fast", slow(0)), Named("slow", slow(100*time.Millisecond)))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, g.Start(ctx), "the group keeps waiting after the watchdog fires")
+		assert.Contains(t, buf.String(), "shutdown hasn't completed within 20ms, still running: fast, slow\n\n")
+		assert.Contains(t, buf.String(), "goroutine ")
+		assert.Contains(t, buf.String(), "runy.TestShutdownWatchdog")
+	})
+
+	t.Run("file", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "goroutines.txt")
+		g := NewGroup(WithShutdownWatchdogFile(20*time.Millisecond, name))
+		g.Add(Named("slow", slow(100*time.Millisecond)))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, g.Start(ctx))
+		b, err := os.ReadFile(name)
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), "still running: slow")
+	})
+
+	t.Run("not fired", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "goroutines.txt")
+		g := NewGroup(WithShutdownWatchdogFile(time.Second, name))
+		g.Add(Named("fast", slow(0)))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, g.Start(ctx))
+		assert.NoFileExists(t, name)
+	})
+
+	t.Run("open error", func(t *testing.T) {
+		l := &recordingLogger{}
+		name := filepath.Join(t.TempDir(), "missing", "goroutines.txt")
+		g := NewGroup(WithShutdownWatchdogFile(time.Millisecond, name), WithLogAdapter(l))
+		g.Add(Named("slow", slow(50*time.Millisecond)))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, g.Start(ctx))
+		assert.Contains(t, l.get(), "WARN shutdown watchdog fired")
+		assert.Contains(t, l.get(), "ERROR failed to write shutdown diagnostics")
+	})
+}